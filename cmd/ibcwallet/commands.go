@@ -0,0 +1,182 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	bip39 "github.com/tyler-smith/go-bip39"
+
+	"0fatih/yui-ibc-solidity/pkg/signer"
+	ibctesting "0fatih/yui-ibc-solidity/pkg/testing"
+	"0fatih/yui-ibc-solidity/pkg/wallet"
+)
+
+// the default path of the first account, whose index is replaced with the derived ones
+const defaultPath = "m/44'/60'/0'/0/0"
+
+func runMnemonic(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("mnemonic", flag.ContinueOnError)
+	bits := fs.Int("bits", 256, "entropy size in bits: 128 (12 words) to 256 (24 words) in multiples of 32")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	entropy, err := bip39.NewEntropy(*bits)
+	if err != nil {
+		return err
+	}
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(stdout, mnemonic)
+	return err
+}
+
+func runAccounts(args []string, getenv func(string) string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("accounts", flag.ContinueOnError)
+	path := fs.String("path", defaultPath, "BIP-44 path whose address index is replaced with the derived ones")
+	from := fs.Uint("from", 0, "first address index")
+	count := fs.Uint("count", 10, "number of the accounts")
+	privateKeys := fs.Bool("private-keys", false, "print the private keys as well")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	seed, err := seedFromEnv(getenv, envMnemonic)
+	if err != nil {
+		return err
+	}
+	hp, err := wallet.ParseHDPathLevel(*path)
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < uint32(*count); i++ {
+		hp.Index = uint32(*from) + i
+		key, err := wallet.GetPrvKeyFromHDWallet(seed, hp)
+		if err != nil {
+			return err
+		}
+		line := fmt.Sprintf("%v\t%v\t%v", hp.Index, hp, gethcrypto.PubkeyToAddress(key.PublicKey))
+		if *privateKeys {
+			line += "\t" + hexutil.Encode(gethcrypto.FromECDSA(key))
+		}
+		if _, err := fmt.Fprintln(stdout, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runExport(args []string, getenv func(string) string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	path := fs.String("path", defaultPath, "BIP-44 path whose address index is replaced with the index")
+	index := fs.Uint("index", 0, "address index")
+	out := fs.String("out", "", "keystore file to write")
+	light := fs.Bool("light", false, "use the light scrypt parameters, which are only suitable for tests")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *out == "" {
+		return errors.New("-out is required")
+	}
+	passphrase := getenv(envKeystorePassphrase)
+	if passphrase == "" {
+		return fmt.Errorf("environ variable '%v' is empty", envKeystorePassphrase)
+	}
+	seed, err := seedFromEnv(getenv, envMnemonic)
+	if err != nil {
+		return err
+	}
+	hp, err := wallet.ParseHDPathLevel(*path)
+	if err != nil {
+		return err
+	}
+	hp.Index = uint32(*index)
+	prv, err := wallet.GetPrvKeyFromHDWallet(seed, hp)
+	if err != nil {
+		return err
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+	scryptN, scryptP := keystore.StandardScryptN, keystore.StandardScryptP
+	if *light {
+		scryptN, scryptP = keystore.LightScryptN, keystore.LightScryptP
+	}
+	key := &keystore.Key{Id: id, Address: gethcrypto.PubkeyToAddress(prv.PublicKey), PrivateKey: prv}
+	bz, err := keystore.EncryptKey(key, passphrase, scryptN, scryptP)
+	if err != nil {
+		return err
+	}
+	// do not overwrite an existing key
+	f, err := os.OpenFile(*out, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(bz); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(stdout, "%v\t%v\t%v\n", hp, key.Address, *out)
+	return err
+}
+
+// runTesting prints the addresses that pkg/testing and the deploy script use for the mnemonic.
+func runTesting(args []string, getenv func(string) string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("testing", flag.ContinueOnError)
+	simulated := fs.Bool("simulated", false, "use the mnemonic of the simulated chains")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	mnemonic := ibctesting.SimulatedMnemonic
+	if !*simulated {
+		if mnemonic = getenv(envTestMnemonic); mnemonic == "" {
+			return fmt.Errorf("environ variable '%v' is empty", envTestMnemonic)
+		}
+	}
+	// Deploy.s.sol derives the deployer key from TEST_MNEMONIC_INDEX
+	var deployerIndex uint32
+	if v := getenv(envTestMnemonicIndex); v != "" {
+		i, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid %v: %w", envTestMnemonicIndex, err)
+		}
+		deployerIndex = uint32(i)
+	}
+	keyring := signer.NewMnemonicKeyring(mnemonic)
+	for _, account := range []struct {
+		name  string
+		index uint32
+	}{
+		{"relayer", ibctesting.RelayerKeyIndex},
+		{"deployer", deployerIndex},
+	} {
+		s, err := keyring.Signer(account.index)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(stdout, "%v\t%v\t%v\n", account.name, account.index, s.Address()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func seedFromEnv(getenv func(string) string, env string) ([]byte, error) {
+	mnemonic := getenv(env)
+	if mnemonic == "" {
+		return nil, fmt.Errorf("environ variable '%v' is empty", env)
+	}
+	return wallet.NewSeed(mnemonic, getenv(envPassphrase))
+}
@@ -0,0 +1,53 @@
+// Command ibcwallet generates mnemonics and derives the keys and the addresses used by the relayer and the tests.
+//
+// Usage:
+//
+//	ibcwallet mnemonic [-bits 256]
+//	IBCWALLET_MNEMONIC="..." ibcwallet accounts [-path "m/44'/60'/0'/0/0"] [-from 0] [-count 10] [-private-keys]
+//	IBCWALLET_MNEMONIC="..." IBCWALLET_KEYSTORE_PASSPHRASE="..." ibcwallet export -index 0 -out key.json
+//	TEST_MNEMONIC="..." ibcwallet testing [-simulated]
+//
+// The BIP-39 passphrase of the mnemonic is read from IBCWALLET_PASSPHRASE if it is set.
+// The secrets are read from environ variables so that they do not remain in the shell history.
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+)
+
+const (
+	envMnemonic           = "IBCWALLET_MNEMONIC"
+	envPassphrase         = "IBCWALLET_PASSPHRASE"
+	envKeystorePassphrase = "IBCWALLET_KEYSTORE_PASSPHRASE"
+	envTestMnemonic       = "TEST_MNEMONIC"
+	envTestMnemonicIndex  = "TEST_MNEMONIC_INDEX"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Getenv, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run executes the subcommand of the args. It is separated from main for the tests.
+func run(args []string, getenv func(string) string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: ibcwallet <mnemonic|accounts|export|testing> [flags]")
+	}
+	cmd, args := args[0], args[1:]
+	switch cmd {
+	case "mnemonic":
+		return runMnemonic(args, stdout)
+	case "accounts":
+		return runAccounts(args, getenv, stdout)
+	case "export":
+		return runExport(args, getenv, stdout)
+	case "testing":
+		return runTesting(args, getenv, stdout)
+	default:
+		return fmt.Errorf("unknown command: %v", cmd)
+	}
+}
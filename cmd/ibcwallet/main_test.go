@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	bip39 "github.com/tyler-smith/go-bip39"
+
+	"0fatih/yui-ibc-solidity/pkg/signer"
+)
+
+// the mnemonic of the development accounts of Hardhat and Anvil
+const testMnemonic = "test test test test test test test test test test test junk"
+
+func runWithEnv(t *testing.T, env map[string]string, args ...string) (string, error) {
+	var stdout bytes.Buffer
+	err := run(args, func(key string) string { return env[key] }, &stdout)
+	return stdout.String(), err
+}
+
+func TestMnemonic(t *testing.T) {
+	out, err := runWithEnv(t, nil, "mnemonic", "-bits", "128")
+	require.NoError(t, err)
+	mnemonic := strings.TrimSpace(out)
+	require.True(t, bip39.IsMnemonicValid(mnemonic))
+	require.Len(t, strings.Fields(mnemonic), 12)
+
+	_, err = runWithEnv(t, nil, "mnemonic", "-bits", "100")
+	require.Error(t, err)
+}
+
+func TestAccounts(t *testing.T) {
+	env := map[string]string{envMnemonic: testMnemonic}
+	out, err := runWithEnv(t, env, "accounts", "-from", "1", "-count", "2", "-private-keys")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 2)
+	require.Equal(t, []string{
+		"1", "m/44'/60'/0'/0/1", "0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+		"0x59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d",
+	}, strings.Split(lines[0], "\t"))
+
+	_, err = runWithEnv(t, nil, "accounts")
+	require.Error(t, err)
+	_, err = runWithEnv(t, env, "accounts", "-path", "m/0/1")
+	require.Error(t, err)
+}
+
+func TestExport(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "key.json")
+	env := map[string]string{envMnemonic: testMnemonic, envKeystorePassphrase: "passphrase"}
+	_, err := runWithEnv(t, env, "export", "-index", "1", "-out", out, "-light")
+	require.NoError(t, err)
+	s, err := signer.NewKeystoreSigner(out, "passphrase")
+	require.NoError(t, err)
+	require.Equal(t, "0x70997970C51812dc3A010C7d01b50e0d17dc79C8", s.Address().Hex())
+
+	// an existing file is not overwritten
+	_, err = runWithEnv(t, env, "export", "-out", out, "-light")
+	require.Error(t, err)
+	delete(env, envKeystorePassphrase)
+	_, err = runWithEnv(t, env, "export", "-out", filepath.Join(t.TempDir(), "key.json"), "-light")
+	require.Error(t, err)
+}
+
+func TestTestingAccounts(t *testing.T) {
+	out, err := runWithEnv(t, map[string]string{envTestMnemonic: testMnemonic, envTestMnemonicIndex: "1"}, "testing")
+	require.NoError(t, err)
+	require.Equal(t, "relayer\t0\t0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266\ndeployer\t1\t0x70997970C51812dc3A010C7d01b50e0d17dc79C8\n", out)
+
+	out, err = runWithEnv(t, nil, "testing", "-simulated")
+	require.NoError(t, err)
+	require.Len(t, strings.Split(strings.TrimSpace(out), "\n"), 2)
+}
@@ -0,0 +1,381 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/yaml.v3"
+
+	"0fatih/yui-ibc-solidity/pkg/client"
+	"0fatih/yui-ibc-solidity/pkg/ibc/relay"
+	"0fatih/yui-ibc-solidity/pkg/signer"
+	"0fatih/yui-ibc-solidity/pkg/wallet"
+)
+
+const defaultPollInterval = 5 * time.Second
+
+// Config is the configuration of the relayer. It can be written in JSON or YAML.
+type Config struct {
+	// PollInterval is the interval between two scans of the handler events
+	PollInterval Duration      `json:"poll_interval" yaml:"poll_interval"`
+	Chains       []ChainConfig `json:"chains" yaml:"chains"`
+	Paths        []PathConfig  `json:"paths" yaml:"paths"`
+}
+
+// ChainConfig describes a chain and the IBCHandler deployed on it.
+type ChainConfig struct {
+	Name string `json:"name" yaml:"name"`
+	// RPCAddr is an http(s)://, ws(s):// or IPC endpoint of the chain
+	RPCAddr           string         `json:"rpc_addr" yaml:"rpc_addr"`
+	IBCHandlerAddress common.Address `json:"ibc_handler_address" yaml:"ibc_handler_address"`
+	// CommitmentSlot is the storage slot of the commitments mapping in the IBCHandler. It is detected if it is nil.
+	// The IBFT2 and QBFT clients support the default slot 0 only.
+	CommitmentSlot *common.Hash `json:"commitment_slot" yaml:"commitment_slot"`
+	// IBCChainID is the chain ID used in IBC, e.g. "ibc0-1" for the revision 1. The decimal chain ID is used if it is empty.
+	// The IBFT2 and QBFT clients support only the revision 0.
+	IBCChainID string `json:"ibc_chain_id" yaml:"ibc_chain_id"`
+	// ClientType is the type of the light client that tracks this chain on its counterparties
+	ClientType string `json:"client_type" yaml:"client_type"`
+	// StartHeight is the height from which the handler events are scanned
+	StartHeight uint64 `json:"start_height" yaml:"start_height"`
+	// MaxBlockRange is the number of blocks scanned by an eth_getLogs request. It is index.DefaultMaxBlockRange if zero.
+	MaxBlockRange uint64 `json:"max_block_range" yaml:"max_block_range"`
+	// IndexPath is the file that persists the event index of the chain. The index is kept in memory if it is empty.
+	IndexPath string `json:"index_path" yaml:"index_path"`
+	// Gas decides the gas limit and the fees of the transactions sent to the chain
+	Gas GasConfig `json:"gas" yaml:"gas"`
+	// Signer is the key that signs the transactions sent to the chain
+	Signer SignerConfig `json:"signer" yaml:"signer"`
+}
+
+// the types of SignerConfig
+const (
+	// SignerTypeMnemonic derives the key from the mnemonic in the environ variable RELAYER_MNEMONIC
+	SignerTypeMnemonic = ""
+	// SignerTypeKey reads the hex-encoded private key from an environ variable
+	SignerTypeKey = "key"
+	// SignerTypeKeystore decrypts an encrypted keystore file of go-ethereum
+	SignerTypeKeystore = "keystore"
+	// SignerTypeRemote asks Clef or Web3Signer to sign the transactions
+	SignerTypeRemote = "remote"
+)
+
+// SignerConfig selects the key that signs the transactions. The secrets are read from environ variables
+// so that they are not written in the config file.
+type SignerConfig struct {
+	// Type is "key", "keystore", "remote" or empty to use the mnemonic
+	Type string `json:"type" yaml:"type"`
+	// HDPath is the base path of the mnemonic, to which the key index is appended. It is m/44'/60'/0'/0 if empty.
+	HDPath string `json:"hd_path" yaml:"hd_path"`
+	// KeyEnv is the environ variable that has the private key of the type "key"
+	KeyEnv string `json:"key_env" yaml:"key_env"`
+	// KeystorePath is the keystore file of the type "keystore"
+	KeystorePath string `json:"keystore_path" yaml:"keystore_path"`
+	// PassphraseEnv is the environ variable that has the passphrase of the keystore file or the BIP-39 passphrase
+	// of the mnemonic
+	PassphraseEnv string `json:"passphrase_env" yaml:"passphrase_env"`
+	// Endpoint is the JSON-RPC endpoint of the remote signer
+	Endpoint string `json:"endpoint" yaml:"endpoint"`
+	// API is the API of the remote signer: "clef" or "web3signer"
+	API string `json:"api" yaml:"api"`
+	// Address is the account of the remote signer
+	Address common.Address `json:"address" yaml:"address"`
+}
+
+func (c SignerConfig) Validate() error {
+	var zero common.Address
+	switch c.Type {
+	case SignerTypeMnemonic:
+		if c.HDPath != "" {
+			if _, err := wallet.ParseHDPath(c.HDPath); err != nil {
+				return err
+			}
+		}
+	case SignerTypeKey:
+		if c.KeyEnv == "" {
+			return errors.New("key_env is empty")
+		}
+	case SignerTypeKeystore:
+		if c.KeystorePath == "" {
+			return errors.New("keystore_path is empty")
+		}
+	case SignerTypeRemote:
+		if c.Endpoint == "" {
+			return errors.New("endpoint is empty")
+		} else if c.Address == zero {
+			return errors.New("address is empty")
+		} else if _, err := signer.ParseRemoteAPI(c.API); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown signer type: %v", c.Type)
+	}
+	return nil
+}
+
+// Keyring returns the keyring whose RelayerKeyIndex is the configured key.
+func (c SignerConfig) Keyring(ctx context.Context, mnemonic string) (signer.Keyring, error) {
+	var (
+		s   signer.Signer
+		err error
+	)
+	switch c.Type {
+	case SignerTypeMnemonic:
+		if mnemonic == "" {
+			return nil, errors.New("environ variable 'RELAYER_MNEMONIC' is empty")
+		}
+		base := wallet.DefaultBasePath
+		if c.HDPath != "" {
+			if base, err = wallet.ParseHDPath(c.HDPath); err != nil {
+				return nil, err
+			}
+		}
+		return signer.NewHDKeyring(mnemonic, os.Getenv(c.PassphraseEnv), base), nil
+	case SignerTypeKey:
+		key := os.Getenv(c.KeyEnv)
+		if key == "" {
+			return nil, fmt.Errorf("environ variable '%v' is empty", c.KeyEnv)
+		}
+		s, err = signer.NewPrivateKeySignerFromHex(key)
+	case SignerTypeKeystore:
+		s, err = signer.NewKeystoreSigner(c.KeystorePath, os.Getenv(c.PassphraseEnv))
+	case SignerTypeRemote:
+		var api signer.RemoteAPI
+		if api, err = signer.ParseRemoteAPI(c.API); err == nil {
+			s, err = signer.DialRemoteSigner(ctx, c.Endpoint, c.Address, api)
+		}
+	default:
+		err = fmt.Errorf("unknown signer type: %v", c.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return signer.Keys{relay.RelayerKeyIndex: s}, nil
+}
+
+// the fee strategies of GasConfig
+const (
+	// FeeStrategyNode leaves the fees to the node
+	FeeStrategyNode = ""
+	// FeeStrategyLegacy uses the legacy gas price, e.g. a zero price on a free gas network of Besu
+	FeeStrategyLegacy = "legacy"
+	// FeeStrategyDynamic uses the EIP-1559 fees computed from eth_feeHistory
+	FeeStrategyDynamic = "dynamic"
+)
+
+// GasConfig decides the gas limit and the fees of the transactions. The zero values fall back to the defaults.
+type GasConfig struct {
+	// Limit is the fixed gas limit. The gas of each transaction is estimated if it is zero.
+	Limit uint64 `json:"limit" yaml:"limit"`
+	// EstimateMultiplier is the safety multiplier of the estimated gas
+	EstimateMultiplier float64 `json:"estimate_multiplier" yaml:"estimate_multiplier"`
+	// FeeStrategy is "legacy", "dynamic" or empty to leave the fees to the node
+	FeeStrategy string `json:"fee_strategy" yaml:"fee_strategy"`
+	// GasPrice is the fixed gas price in wei of the legacy strategy. The node suggests the price if it is not set.
+	GasPrice *uint64 `json:"gas_price" yaml:"gas_price"`
+	// RewardPercentile is the percentile of the priority fees sampled by the dynamic strategy
+	RewardPercentile float64 `json:"reward_percentile" yaml:"reward_percentile"`
+	// BaseFeeMultiplier is multiplied by the base fee of the next block in the dynamic strategy
+	BaseFeeMultiplier float64 `json:"base_fee_multiplier" yaml:"base_fee_multiplier"`
+	// MaxFee caps the gas price or the fee cap in wei, including the ones of the replacements. There is no cap if it is zero.
+	MaxFee uint64 `json:"max_fee" yaml:"max_fee"`
+	// ReplaceAfter is how long a transaction is waited for before it is replaced with bumped fees.
+	// The transactions are not replaced if it is zero.
+	ReplaceAfter Duration `json:"replace_after" yaml:"replace_after"`
+	// BumpPercent is the percentage by which the fees of a replacement are raised
+	BumpPercent int64 `json:"bump_percent" yaml:"bump_percent"`
+	// MaxReplacements is the number of the replacements of a transaction
+	MaxReplacements int `json:"max_replacements" yaml:"max_replacements"`
+}
+
+func (c GasConfig) Validate() error {
+	switch c.FeeStrategy {
+	case FeeStrategyNode, FeeStrategyLegacy, FeeStrategyDynamic:
+	default:
+		return fmt.Errorf("unknown fee_strategy: %v", c.FeeStrategy)
+	}
+	if c.EstimateMultiplier < 0 || c.BaseFeeMultiplier < 0 {
+		return errors.New("multipliers must not be negative")
+	} else if c.RewardPercentile < 0 || c.RewardPercentile > 100 {
+		return fmt.Errorf("reward_percentile is out of range: %v", c.RewardPercentile)
+	} else if c.BumpPercent < 0 || c.MaxReplacements < 0 {
+		return errors.New("bump_percent and max_replacements must not be negative")
+	}
+	return nil
+}
+
+// ClientOptions returns the options of the client that applies the configuration.
+func (c GasConfig) ClientOptions() []client.Option {
+	opts := []client.Option{client.WithGasLimit(c.Limit)}
+	if c.EstimateMultiplier != 0 {
+		opts = append(opts, client.WithGasEstimateMultiplier(c.EstimateMultiplier))
+	}
+	var maxFee *big.Int
+	if c.MaxFee != 0 {
+		maxFee = new(big.Int).SetUint64(c.MaxFee)
+	}
+	switch c.FeeStrategy {
+	case FeeStrategyLegacy:
+		strategy := &client.LegacyFeeStrategy{MaxGasPrice: maxFee}
+		if c.GasPrice != nil {
+			strategy.GasPrice = new(big.Int).SetUint64(*c.GasPrice)
+		}
+		opts = append(opts, client.WithFeeStrategy(strategy))
+	case FeeStrategyDynamic:
+		strategy := client.DefaultDynamicFeeStrategy()
+		if c.RewardPercentile != 0 {
+			strategy.RewardPercentile = c.RewardPercentile
+		}
+		if c.BaseFeeMultiplier != 0 {
+			strategy.BaseFeeMultiplier = c.BaseFeeMultiplier
+		}
+		strategy.MaxFeePerGas = maxFee
+		opts = append(opts, client.WithFeeStrategy(strategy))
+	}
+	if c.ReplaceAfter != 0 {
+		policy := client.DefaultReplacementPolicy()
+		policy.Interval = time.Duration(c.ReplaceAfter)
+		if c.BumpPercent != 0 {
+			policy.BumpPercent = c.BumpPercent
+		}
+		if c.MaxReplacements != 0 {
+			policy.MaxReplacements = c.MaxReplacements
+		}
+		policy.MaxFee = maxFee
+		opts = append(opts, client.WithTxReplacement(policy))
+	}
+	return opts
+}
+
+// PathConfig is a pair of channel ends that are relayed in both directions.
+type PathConfig struct {
+	Src PathEndConfig `json:"src" yaml:"src"`
+	Dst PathEndConfig `json:"dst" yaml:"dst"`
+}
+
+// PathEndConfig is a channel end on a chain.
+type PathEndConfig struct {
+	Chain        string `json:"chain" yaml:"chain"`
+	ClientID     string `json:"client_id" yaml:"client_id"`
+	ConnectionID string `json:"connection_id" yaml:"connection_id"`
+	PortID       string `json:"port_id" yaml:"port_id"`
+	ChannelID    string `json:"channel_id" yaml:"channel_id"`
+}
+
+// Duration is a time.Duration that is written as a string such as "5s".
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(bz []byte) error {
+	var s string
+	if err := json.Unmarshal(bz, &s); err != nil {
+		return err
+	}
+	return d.parse(s)
+}
+
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	return d.parse(value.Value)
+}
+
+func (d *Duration) parse(s string) error {
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// LoadConfig reads the configuration from the given path. A file with the extension ".yaml" or ".yml"
+// is parsed as YAML, and any other file is parsed as JSON.
+func LoadConfig(path string) (*Config, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(bz, &config)
+	default:
+		err = json.Unmarshal(bz, &config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config '%v': %w", path, err)
+	}
+	if config.PollInterval == 0 {
+		config.PollInterval = Duration(defaultPollInterval)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+func (c *Config) Validate() error {
+	if len(c.Paths) == 0 {
+		return errors.New("no paths are configured")
+	}
+	names := make(map[string]bool)
+	for _, chain := range c.Chains {
+		if err := chain.Validate(); err != nil {
+			return err
+		} else if names[chain.Name] {
+			return fmt.Errorf("duplicated chain name: %v", chain.Name)
+		}
+		names[chain.Name] = true
+	}
+	for _, path := range c.Paths {
+		for _, end := range []PathEndConfig{path.Src, path.Dst} {
+			if err := end.Validate(); err != nil {
+				return err
+			} else if !names[end.Chain] {
+				return fmt.Errorf("unknown chain: %v", end.Chain)
+			}
+		}
+		if path.Src.Chain == path.Dst.Chain {
+			return fmt.Errorf("both ends of a path are on the same chain: %v", path.Src.Chain)
+		}
+	}
+	return nil
+}
+
+func (c ChainConfig) Validate() error {
+	var zero common.Address
+	if c.Name == "" {
+		return errors.New("chain name is empty")
+	} else if c.RPCAddr == "" {
+		return fmt.Errorf("rpc_addr is empty: chain=%v", c.Name)
+	} else if c.IBCHandlerAddress == zero {
+		return fmt.Errorf("ibc_handler_address is empty: chain=%v", c.Name)
+	} else if c.ClientType == "" {
+		return fmt.Errorf("client_type is empty: chain=%v", c.Name)
+	} else if err := c.Gas.Validate(); err != nil {
+		return fmt.Errorf("invalid gas config: chain=%v: %w", c.Name, err)
+	} else if err := c.Signer.Validate(); err != nil {
+		return fmt.Errorf("invalid signer config: chain=%v: %w", c.Name, err)
+	}
+	return nil
+}
+
+func (c PathEndConfig) Validate() error {
+	if c.Chain == "" {
+		return errors.New("chain of path end is empty")
+	} else if c.ClientID == "" {
+		return fmt.Errorf("client_id is empty: chain=%v", c.Chain)
+	} else if c.ConnectionID == "" {
+		return fmt.Errorf("connection_id is empty: chain=%v", c.Chain)
+	} else if c.PortID == "" {
+		return fmt.Errorf("port_id is empty: chain=%v", c.Chain)
+	} else if c.ChannelID == "" {
+		return fmt.Errorf("channel_id is empty: chain=%v", c.Chain)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	config, err := LoadConfig("relayer.example.yaml")
+	require.NoError(t, err)
+	require.Equal(t, 5*time.Second, time.Duration(config.PollInterval))
+	require.Len(t, config.Chains, 2)
+	require.Len(t, config.Paths, 1)
+	require.Equal(t, "ibc1", config.Paths[0].Dst.Chain)
+	require.Equal(t, uint64(1000), config.Chains[0].MaxBlockRange)
+	require.Equal(t, FeeStrategyLegacy, config.Chains[0].Gas.FeeStrategy)
+	require.Equal(t, uint64(0), *config.Chains[0].Gas.GasPrice)
+	require.Equal(t, uint64(100000000000), config.Chains[1].Gas.MaxFee)
+	require.Equal(t, 2*time.Minute, time.Duration(config.Chains[1].Gas.ReplaceAfter))
+	require.Equal(t, SignerTypeKeystore, config.Chains[1].Signer.Type)
+
+	path := filepath.Join(t.TempDir(), "relayer.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"chains": [
+			{"name": "a", "rpc_addr": "http://127.0.0.1:8645", "ibc_handler_address": "0xaa43d337145E8930d01cb4E60Abf6595C692921E", "client_type": "mock-client"},
+			{"name": "b", "rpc_addr": "http://127.0.0.1:8745", "ibc_handler_address": "0xaa43d337145E8930d01cb4E60Abf6595C692921E", "client_type": "mock-client"}
+		],
+		"paths": [{
+			"src": {"chain": "a", "client_id": "mock-client-0", "connection_id": "connection-0", "port_id": "transfer", "channel_id": "channel-0"},
+			"dst": {"chain": "b", "client_id": "mock-client-0", "connection_id": "connection-0", "port_id": "transfer", "channel_id": "channel-0"}
+		}]
+	}`), 0o600))
+	config, err = LoadConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, defaultPollInterval, time.Duration(config.PollInterval))
+
+	config.Chains[1].Signer = SignerConfig{Type: SignerTypeRemote, Endpoint: "http://127.0.0.1:8550", API: "clef"}
+	require.Error(t, config.Validate())
+	config.Chains[1].Signer.Address = common.Address{1}
+	require.NoError(t, config.Validate())
+	config.Chains[1].Signer.API = "unknown"
+	require.Error(t, config.Validate())
+	config.Chains[1].Signer = SignerConfig{}
+
+	config.Chains[0].Gas.FeeStrategy = "unknown"
+	require.Error(t, config.Validate())
+	config.Chains[0].Gas.FeeStrategy = FeeStrategyDynamic
+	require.NoError(t, config.Validate())
+
+	config.Paths[0].Dst.Chain = "c"
+	require.Error(t, config.Validate())
+	config.Paths[0].Dst.Chain = "a"
+	require.Error(t, config.Validate())
+}
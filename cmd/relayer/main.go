@@ -0,0 +1,44 @@
+// Command relayer relays packets and acknowledgements between the IBCHandlers on EVM chains. The packets that have
+// timed out on the destination chain, or whose destination channel is closed, are timed out on the source chain.
+//
+// Usage:
+//
+//	RELAYER_MNEMONIC="..." relayer -config config.yaml
+//
+// The chains, clients, connections and channel paths are read from the config file (see Config).
+// RELAYER_MNEMONIC is not needed if every chain has a signer configured (see SignerConfig).
+// The clients, connections and channels must be created in advance.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	configPath := flag.String("config", "relayer.yaml", "path to the config file (YAML or JSON)")
+	flag.Parse()
+
+	mnemonic := os.Getenv("RELAYER_MNEMONIC")
+	config, err := LoadConfig(*configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	relayer, err := NewRelayer(config, mnemonic)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	log.Printf("relayer started: paths=%v", len(config.Paths))
+	if err := relayer.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		log.Fatal(err)
+	}
+	log.Print("relayer stopped")
+}
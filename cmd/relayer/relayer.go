@@ -0,0 +1,387 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"0fatih/yui-ibc-solidity/pkg/client"
+	"0fatih/yui-ibc-solidity/pkg/contract/ibchandler"
+	channeltypes "0fatih/yui-ibc-solidity/pkg/ibc/core/channel"
+	clienttypes "0fatih/yui-ibc-solidity/pkg/ibc/core/client"
+	"0fatih/yui-ibc-solidity/pkg/ibc/relay"
+	"0fatih/yui-ibc-solidity/pkg/index"
+)
+
+// Relayer relays packets and acknowledgements over the configured paths.
+type Relayer struct {
+	coord        relay.Coordinator
+	chains       map[string]*relay.Chain
+	paths        []*relayPath
+	pollInterval time.Duration
+	// the number of blocks scanned by an eth_getLogs request per chain
+	maxBlockRanges map[string]uint64
+}
+
+// relayPath is one direction of a configured path. The packets sent on src are received on dst, and
+// the acknowledgements written on dst are returned to src.
+type relayPath struct {
+	src, dst PathEndConfig
+	// the next heights to be scanned for SendPacket events on src and WriteAcknowledgement events on dst
+	sendCursor, ackCursor uint64
+}
+
+func (p relayPath) String() string {
+	return fmt.Sprintf("%v/%v/%v -> %v/%v/%v", p.src.Chain, p.src.PortID, p.src.ChannelID, p.dst.Chain, p.dst.PortID, p.dst.ChannelID)
+}
+
+// srcChannel returns the source channel end
+func (p relayPath) srcChannel() relay.Channel {
+	return relay.Channel{
+		PortID:               p.src.PortID,
+		ID:                   p.src.ChannelID,
+		ClientID:             p.src.ClientID,
+		CounterpartyClientID: p.dst.ClientID,
+	}
+}
+
+// dstChannel returns the destination channel end
+func (p relayPath) dstChannel() relay.Channel {
+	return relay.Channel{
+		PortID:               p.dst.PortID,
+		ID:                   p.dst.ChannelID,
+		ClientID:             p.dst.ClientID,
+		CounterpartyClientID: p.src.ClientID,
+	}
+}
+
+// NewRelayer connects to the configured chains. The transactions are signed with the configured signer of
+// each chain, or the key of `relay.RelayerKeyIndex` derived from the mnemonic if it is not configured.
+func NewRelayer(config *Config, mnemonic string) (*Relayer, error) {
+	chains := make(map[string]*relay.Chain)
+	var cs []*relay.Chain
+	maxBlockRanges := make(map[string]uint64)
+	for _, cc := range config.Chains {
+		maxBlockRange := cc.MaxBlockRange
+		if maxBlockRange == 0 {
+			maxBlockRange = index.DefaultMaxBlockRange
+		}
+		maxBlockRanges[cc.Name] = maxBlockRange
+		ethClient, err := client.NewETHClient(cc.RPCAddr, cc.Gas.ClientOptions()...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to chain '%v': %w", cc.Name, err)
+		}
+		keyring, err := cc.Signer.Keyring(context.TODO(), mnemonic)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the signer of chain '%v': %w", cc.Name, err)
+		}
+		chain, err := relay.NewChain(
+			context.TODO(),
+			ethClient,
+			relay.NewLightClient(ethClient, cc.ClientType),
+			keyring,
+			relay.ContractConfig{IBCHandlerAddress: cc.IBCHandlerAddress, CommitmentSlot: cc.CommitmentSlot},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize chain '%v': %w", cc.Name, err)
+		}
+		if cc.IBCChainID != "" {
+			if err := chain.SetChainIDString(cc.IBCChainID); err != nil {
+				return nil, fmt.Errorf("failed to initialize chain '%v': %w", cc.Name, err)
+			}
+		}
+		if cc.IndexPath != "" {
+			if chain.EventIndex, err = index.Open(cc.IndexPath, cc.IBCHandlerAddress, index.WithMaxBlockRange(maxBlockRange)); err != nil {
+				return nil, fmt.Errorf("failed to open the event index of chain '%v': %w", cc.Name, err)
+			}
+		}
+		chains[cc.Name] = chain
+		cs = append(cs, chain)
+	}
+	startHeights := make(map[string]uint64)
+	for _, cc := range config.Chains {
+		startHeights[cc.Name] = cc.StartHeight
+	}
+	var paths []*relayPath
+	for _, pc := range config.Paths {
+		for _, ends := range [][2]PathEndConfig{{pc.Src, pc.Dst}, {pc.Dst, pc.Src}} {
+			paths = append(paths, &relayPath{
+				src:        ends[0],
+				dst:        ends[1],
+				sendCursor: startHeights[ends[0].Chain],
+				ackCursor:  startHeights[ends[1].Chain],
+			})
+		}
+	}
+
+	coord, err := relay.NewCoordinator(context.TODO(), cs...)
+	if err != nil {
+		return nil, err
+	}
+	return &Relayer{
+		coord:          coord,
+		chains:         chains,
+		paths:          paths,
+		pollInterval:   time.Duration(config.PollInterval),
+		maxBlockRanges: maxBlockRanges,
+	}, nil
+}
+
+// Run relays packets until the context is canceled.
+func (r *Relayer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+	for {
+		for _, path := range r.paths {
+			if err := r.relayPackets(ctx, path); err != nil {
+				log.Printf("failed to relay packets: path=%v err=%v", path, err)
+			}
+			if err := r.relayAcknowledgements(ctx, path); err != nil {
+				log.Printf("failed to relay acknowledgements: path=%v err=%v", path, err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// relayPackets relays the packets sent on src in the blocks from the cursor to the latest one. The logs are scanned
+// in ranges of at most the max block range of src, and the cursor advances as the packets are handled.
+func (r *Relayer) relayPackets(ctx context.Context, path *relayPath) error {
+	src := r.chains[path.src.Chain]
+	latest, err := src.Client().BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	for path.sendCursor <= latest {
+		end := blockRangeEnd(path.sendCursor, r.maxBlockRanges[path.src.Chain], latest)
+		if err := r.relayPacketRange(ctx, path, end); err != nil {
+			return err
+		}
+		path.sendCursor = end + 1
+	}
+	return nil
+}
+
+// relayPacketRange submits RecvPacket on dst for the packets sent on src from the cursor to `end` that have not been
+// received yet. The packets that have timed out on dst, or all of them if the dst channel is closed, are timed out on
+// src instead. A packet that fails to be relayed is logged and skipped.
+func (r *Relayer) relayPacketRange(ctx context.Context, path *relayPath, end uint64) error {
+	src, dst := r.chains[path.src.Chain], r.chains[path.dst.Chain]
+	iter, err := src.IBCHandler.FilterSendPacket(&bind.FilterOpts{Start: path.sendCursor, End: &end, Context: ctx})
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	var events []*ibchandler.IbchandlerSendPacket
+	for iter.Next() {
+		if ev := iter.Event; ev.SourcePort == path.src.PortID && ev.SourceChannel == path.src.ChannelID {
+			events = append(events, ev)
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	dstChannel, err := dst.QueryChannel(ctx, path.dst.PortID, path.dst.ChannelID)
+	if err != nil {
+		return err
+	}
+	// an ORDERED channel has received the packets whose sequences are less than the next receive sequence
+	var nextSequenceRecv uint64
+	if dstChannel.Ordering == channeltypes.ORDERED {
+		if nextSequenceRecv, err = dst.QueryNextSequenceRecv(ctx, path.dst.PortID, path.dst.ChannelID); err != nil {
+			return err
+		}
+	}
+	// the packets are checked for the timeout at the latest header of dst, to which the client on src is updated
+	if err := dst.UpdateHeader(ctx); err != nil {
+		return err
+	}
+	var packets []relayedPacket
+	var recvs, timeouts int
+	for _, ev := range events {
+		var received bool
+		if dstChannel.Ordering == channeltypes.ORDERED {
+			received = ev.Sequence < nextSequenceRecv
+		} else if received, err = dst.IBCHandler.HasPacketReceipt(dst.CallOpts(ctx, relay.RelayerKeyIndex), path.dst.PortID, path.dst.ChannelID, ev.Sequence); err != nil {
+			return err
+		}
+		if received {
+			continue
+		}
+		p := relayedPacket{
+			packet: channeltypes.Packet{
+				Sequence:           ev.Sequence,
+				SourcePort:         ev.SourcePort,
+				SourceChannel:      ev.SourceChannel,
+				DestinationPort:    path.dst.PortID,
+				DestinationChannel: path.dst.ChannelID,
+				Data:               ev.Data,
+				TimeoutHeight:      clienttypes.NewHeightFromCallData(ev.TimeoutHeight),
+				TimeoutTimestamp:   ev.TimeoutTimestamp,
+			},
+			blockNumber: ev.Raw.BlockNumber,
+		}
+		p.timeout = dstChannel.State == channeltypes.CLOSED || timedOut(p.packet, dst.LastHeight(), dst.LastHeader())
+		if p.timeout {
+			timeouts++
+		} else {
+			recvs++
+		}
+		packets = append(packets, p)
+	}
+
+	if recvs > 0 {
+		// the client on dst must be updated to a height that includes the packet commitments
+		if err := src.UpdateHeader(ctx); err != nil {
+			return err
+		}
+		if err := r.coord.UpdateClient(ctx, dst, src, path.dst.ClientID); err != nil {
+			return fmt.Errorf("failed to update client '%v': %w", path.dst.ClientID, err)
+		}
+	}
+	if timeouts > 0 {
+		// the client on src must be updated to the header of dst at which the packets have timed out
+		if err := r.coord.UpdateClient(ctx, src, dst, path.src.ClientID); err != nil {
+			return fmt.Errorf("failed to update client '%v': %w", path.src.ClientID, err)
+		}
+	}
+	for _, p := range packets {
+		switch {
+		case !p.timeout:
+			if err := dst.HandlePacketRecv(ctx, src, path.dstChannel(), path.srcChannel(), p.packet); err != nil {
+				log.Printf("failed to receive packet: path=%v sequence=%v err=%v", path, p.packet.Sequence, err)
+			} else {
+				log.Printf("received packet: path=%v sequence=%v", path, p.packet.Sequence)
+			}
+		case dstChannel.State == channeltypes.CLOSED:
+			if err := src.HandlePacketTimeoutOnClose(ctx, dst, path.srcChannel(), path.dstChannel(), p.packet); err != nil {
+				log.Printf("failed to time out packet on close: path=%v sequence=%v err=%v", path, p.packet.Sequence, err)
+			} else {
+				log.Printf("timed out packet on close: path=%v sequence=%v", path, p.packet.Sequence)
+			}
+		default:
+			if err := src.HandlePacketTimeout(ctx, dst, path.srcChannel(), path.dstChannel(), p.packet); err != nil {
+				log.Printf("failed to time out packet: path=%v sequence=%v err=%v", path, p.packet.Sequence, err)
+			} else {
+				log.Printf("timed out packet: path=%v sequence=%v", path, p.packet.Sequence)
+			}
+		}
+		// the packets of the same block are checked again if the relay is interrupted
+		path.sendCursor = p.blockNumber
+	}
+	return nil
+}
+
+// relayedPacket is a packet that dst has not received yet
+type relayedPacket struct {
+	packet channeltypes.Packet
+	// the block of src in which the packet is sent
+	blockNumber uint64
+	// whether the packet is timed out on src instead of being received on dst
+	timeout bool
+}
+
+// timedOut reports whether the packet can no longer be received at the header of dst, i.e. whether timeoutPacket
+// accepts a proof at the height of the header.
+func timedOut(packet channeltypes.Packet, height clienttypes.Height, header *gethtypes.Header) bool {
+	if !packet.TimeoutHeight.IsZero() && height.GTE(packet.TimeoutHeight) {
+		return true
+	}
+	// the timeout timestamp is in nanoseconds, and the header time is in seconds
+	return packet.TimeoutTimestamp != 0 && header.Time*uint64(time.Second) >= packet.TimeoutTimestamp
+}
+
+// relayAcknowledgements relays the acknowledgements written on dst in the blocks from the cursor to the latest one.
+// The logs are scanned in ranges of at most the max block range of dst, and the cursor advances as the
+// acknowledgements are handled.
+func (r *Relayer) relayAcknowledgements(ctx context.Context, path *relayPath) error {
+	dst := r.chains[path.dst.Chain]
+	latest, err := dst.Client().BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	for path.ackCursor <= latest {
+		end := blockRangeEnd(path.ackCursor, r.maxBlockRanges[path.dst.Chain], latest)
+		if err := r.relayAcknowledgementRange(ctx, path, end); err != nil {
+			return err
+		}
+		path.ackCursor = end + 1
+	}
+	return nil
+}
+
+// relayAcknowledgementRange submits AcknowledgePacket on src for the acknowledgements written on dst from the cursor
+// to `end` whose packet commitments still exist on src. An acknowledgement that fails to be relayed is logged and
+// skipped.
+func (r *Relayer) relayAcknowledgementRange(ctx context.Context, path *relayPath, end uint64) error {
+	src, dst := r.chains[path.src.Chain], r.chains[path.dst.Chain]
+	iter, err := dst.IBCHandler.FilterWriteAcknowledgement(&bind.FilterOpts{Start: path.ackCursor, End: &end, Context: ctx})
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	var acks []*ibchandler.IbchandlerWriteAcknowledgement
+	for iter.Next() {
+		ev := iter.Event
+		if ev.DestinationPortId != path.dst.PortID || ev.DestinationChannel != path.dst.ChannelID {
+			continue
+		}
+		_, found, err := src.IBCHandler.GetHashedPacketCommitment(src.CallOpts(ctx, relay.RelayerKeyIndex), path.src.PortID, path.src.ChannelID, ev.Sequence)
+		if err != nil {
+			return err
+		} else if !found {
+			// already acknowledged
+			continue
+		}
+		acks = append(acks, ev)
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	if len(acks) == 0 {
+		return nil
+	}
+
+	// the client on src must be updated to a height that includes the acknowledgement commitments
+	if err := dst.UpdateHeader(ctx); err != nil {
+		return err
+	}
+	if err := r.coord.UpdateClient(ctx, src, dst, path.src.ClientID); err != nil {
+		return fmt.Errorf("failed to update client '%v': %w", path.src.ClientID, err)
+	}
+	for _, ack := range acks {
+		if packet, err := src.FindPacket(ctx, path.src.PortID, path.src.ChannelID, ack.Sequence); err != nil {
+			log.Printf("failed to find packet: path=%v sequence=%v err=%v", path, ack.Sequence, err)
+		} else if err := src.HandlePacketAcknowledgement(ctx, dst, path.srcChannel(), path.dstChannel(), *packet, ack.Acknowledgement); err != nil {
+			log.Printf("failed to acknowledge packet: path=%v sequence=%v err=%v", path, ack.Sequence, err)
+		} else {
+			log.Printf("acknowledged packet: path=%v sequence=%v", path, ack.Sequence)
+		}
+		// the acknowledgements of the same block are checked again if the relay is interrupted
+		path.ackCursor = ack.Raw.BlockNumber
+	}
+	return nil
+}
+
+// blockRangeEnd returns the last block of the range that starts at `start` and has at most `maxBlockRange` blocks
+// up to `latest`.
+func blockRangeEnd(start, maxBlockRange, latest uint64) uint64 {
+	if end := start + maxBlockRange - 1; end < latest {
+		return end
+	}
+	return latest
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	channeltypes "0fatih/yui-ibc-solidity/pkg/ibc/core/channel"
+	clienttypes "0fatih/yui-ibc-solidity/pkg/ibc/core/client"
+)
+
+func TestBlockRangeEnd(t *testing.T) {
+	require.Equal(t, uint64(109), blockRangeEnd(100, 10, 200))
+	require.Equal(t, uint64(105), blockRangeEnd(100, 10, 105))
+	require.Equal(t, uint64(100), blockRangeEnd(100, 1, 200))
+}
+
+func TestTimedOut(t *testing.T) {
+	header := &gethtypes.Header{Number: big.NewInt(100), Time: 1_000_000}
+	height := clienttypes.NewHeightFromBN(header.Number)
+	now := uint64(time.Unix(int64(header.Time), 0).UnixNano())
+
+	// a packet without timeouts never times out
+	require.False(t, timedOut(channeltypes.Packet{}, height, header))
+
+	// the timeout height and timestamp are inclusive as in timeoutPacket
+	require.True(t, timedOut(channeltypes.Packet{TimeoutHeight: clienttypes.NewHeight(0, 100)}, height, header))
+	require.False(t, timedOut(channeltypes.Packet{TimeoutHeight: clienttypes.NewHeight(0, 101)}, height, header))
+	require.True(t, timedOut(channeltypes.Packet{TimeoutTimestamp: now}, height, header))
+	require.False(t, timedOut(channeltypes.Packet{TimeoutTimestamp: now + 1}, height, header))
+}
@@ -56,6 +56,10 @@ abstract contract ICS20Transfer is IBCAppBase {
         }
     }
 
+    function onTimeoutPacket(Packet.Data calldata packet, address) external virtual override onlyIBC {
+        _refundTokens(FungibleTokenPacketData.decode(packet.data), packet.source_port, packet.source_channel);
+    }
+
     function onChanOpenInit(
         Channel.Order,
         string[] calldata,
@@ -70,7 +70,8 @@ contract IBFT2Client is ILightClient {
         ConsensusState.Data memory consensusState;
 
         (clientState, ok) = unmarshalClientState(clientStateBytes);
-        if (!ok) {
+        // the heights of the headers have the revision number 0 (see parseBesuHeader)
+        if (!ok || clientState.latest_height.revision_number != 0) {
             return (clientStateCommitment, update, false);
         }
         (consensusState, ok) = unmarshalConsensusState(consensusStateBytes);
@@ -36,6 +36,7 @@ contract IBCChannelHandshake is IBCStore, IIBCChannelHandshake {
         nextSequenceRecvs[msg_.portId][channelId] = 1;
         nextSequenceAcks[msg_.portId][channelId] = 1;
         updateChannelCommitment(msg_.portId, channelId);
+        updateNextSequenceRecvCommitment(msg_.portId, channelId);
         return channelId;
     }
 
@@ -80,6 +81,7 @@ contract IBCChannelHandshake is IBCStore, IIBCChannelHandshake {
         nextSequenceRecvs[msg_.portId][channelId] = 1;
         nextSequenceAcks[msg_.portId][channelId] = 1;
         updateChannelCommitment(msg_.portId, channelId);
+        updateNextSequenceRecvCommitment(msg_.portId, channelId);
         return channelId;
     }
 
@@ -210,6 +212,11 @@ contract IBCChannelHandshake is IBCStore, IIBCChannelHandshake {
             keccak256(Channel.encode(channels[portId][channelId]));
     }
 
+    function updateNextSequenceRecvCommitment(string memory portId, string memory channelId) private {
+        commitments[IBCCommitment.nextSequenceRecvCommitmentKey(portId, channelId)] =
+            keccak256(abi.encodePacked(nextSequenceRecvs[portId][channelId]));
+    }
+
     /* Verification functions */
 
     function verifyChannelState(
@@ -16,6 +16,9 @@ import "../04-channel/IIBCChannel.sol";
 contract IBCPacket is IBCStore, IIBCPacket {
     using IBCHeight for Height.Data;
 
+    // the value of a packet receipt, whose commitment is stored when an UNORDERED channel receives the packet
+    uint8 private constant PACKET_RECEIPT_SUCCESSFUL = 1;
+
     /* Packet handlers */
 
     /**
@@ -119,13 +122,22 @@ contract IBCPacket is IBCStore, IIBCPacket {
                 packetReceipts[msg_.packet.destination_port][msg_.packet.destination_channel][msg_.packet.sequence] == 0,
                 "packet sequence already has been received"
             );
-            packetReceipts[msg_.packet.destination_port][msg_.packet.destination_channel][msg_.packet.sequence] = 1;
+            packetReceipts[msg_.packet.destination_port][msg_.packet.destination_channel][msg_.packet.sequence] =
+                PACKET_RECEIPT_SUCCESSFUL;
+            commitments[IBCCommitment.packetReceiptCommitmentKey(
+                msg_.packet.destination_port, msg_.packet.destination_channel, msg_.packet.sequence
+            )] = keccak256(abi.encodePacked(PACKET_RECEIPT_SUCCESSFUL));
         } else if (channel.ordering == Channel.Order.ORDER_ORDERED) {
             require(
                 nextSequenceRecvs[msg_.packet.destination_port][msg_.packet.destination_channel] == msg_.packet.sequence,
                 "packet sequence != next receive sequence"
             );
             nextSequenceRecvs[msg_.packet.destination_port][msg_.packet.destination_channel]++;
+            commitments[IBCCommitment.nextSequenceRecvCommitmentKey(
+                msg_.packet.destination_port, msg_.packet.destination_channel
+            )] = keccak256(
+                abi.encodePacked(nextSequenceRecvs[msg_.packet.destination_port][msg_.packet.destination_channel])
+            );
         } else {
             revert("unknown ordering type");
         }
@@ -177,26 +189,7 @@ contract IBCPacket is IBCStore, IIBCPacket {
         ConnectionEnd.Data storage connection = connections[channel.connection_hops[0]];
         require(connection.state == ConnectionEnd.State.STATE_OPEN, "connection state is not OPEN");
 
-        bytes32 packetCommitmentKey =
-            IBCCommitment.packetCommitmentKey(msg_.packet.source_port, msg_.packet.source_channel, msg_.packet.sequence);
-        bytes32 packetCommitment = commitments[packetCommitmentKey];
-        require(packetCommitment != bytes32(0), "packet commitment not found");
-        require(
-            packetCommitment
-                == keccak256(
-                    abi.encodePacked(
-                        sha256(
-                            abi.encodePacked(
-                                msg_.packet.timeout_timestamp,
-                                msg_.packet.timeout_height.revision_number,
-                                msg_.packet.timeout_height.revision_height,
-                                sha256(msg_.packet.data)
-                            )
-                        )
-                    )
-                ),
-            "commitment bytes are not equal"
-        );
+        bytes32 packetCommitmentKey = checkPacketCommitment(msg_.packet);
 
         require(
             verifyPacketAcknowledgement(
@@ -222,10 +215,182 @@ contract IBCPacket is IBCStore, IIBCPacket {
         delete commitments[packetCommitmentKey];
     }
 
+    /**
+     * @dev timeoutPacket is called by a module in order to process a packet that has timed out on the counterparty
+     * chain. The packet must not have been received, which is proven by the absence of the packet receipt for
+     * UNORDERED channels and by the next receive sequence for ORDERED channels. An ORDERED channel is closed after
+     * the timeout.
+     */
+    function timeoutPacket(IBCMsgs.MsgTimeoutPacket calldata msg_) external {
+        Channel.Data storage channel = channels[msg_.packet.source_port][msg_.packet.source_channel];
+        require(channel.state == Channel.State.STATE_OPEN, "channel state must be OPEN");
+
+        require(
+            hashString(msg_.packet.destination_port) == hashString(channel.counterparty.port_id),
+            "packet destination port doesn't match the counterparty's port"
+        );
+        require(
+            hashString(msg_.packet.destination_channel) == hashString(channel.counterparty.channel_id),
+            "packet destination channel doesn't match the counterparty's channel"
+        );
+
+        ConnectionEnd.Data storage connection = connections[channel.connection_hops[0]];
+
+        {
+            ILightClient client = checkAndGetClient(connection.client_id);
+            (uint64 proofTimestamp, bool found) = client.getTimestampAtHeight(connection.client_id, msg_.proofHeight);
+            require(found, "consensusState not found");
+            require(
+                (!msg_.packet.timeout_height.isZero() && msg_.proofHeight.gte(msg_.packet.timeout_height))
+                    || (msg_.packet.timeout_timestamp != 0 && proofTimestamp >= msg_.packet.timeout_timestamp),
+                "packet timeout has not been reached for height or timestamp"
+            );
+        }
+
+        bytes32 packetCommitmentKey = checkPacketCommitment(msg_.packet);
+        verifyPacketNotReceived(
+            connection, channel.ordering, msg_.packet, msg_.proofHeight, msg_.proof, msg_.nextSequenceRecv
+        );
+
+        delete commitments[packetCommitmentKey];
+        if (channel.ordering == Channel.Order.ORDER_ORDERED) {
+            closeChannel(channel, msg_.packet.source_port, msg_.packet.source_channel);
+        }
+    }
+
+    /**
+     * @dev timeoutOnClose is called by a module in order to process a packet that can no longer be received because
+     * the counterparty channel has been closed, which is proven instead of the timeout. The packet must not have been
+     * received as in timeoutPacket. An ORDERED channel is closed after the timeout.
+     */
+    function timeoutOnClose(IBCMsgs.MsgTimeoutOnClose calldata msg_) external {
+        Channel.Data storage channel = channels[msg_.packet.source_port][msg_.packet.source_channel];
+        require(channel.state != Channel.State.STATE_UNINITIALIZED_UNSPECIFIED, "channel not found");
+
+        require(
+            hashString(msg_.packet.destination_port) == hashString(channel.counterparty.port_id),
+            "packet destination port doesn't match the counterparty's port"
+        );
+        require(
+            hashString(msg_.packet.destination_channel) == hashString(channel.counterparty.channel_id),
+            "packet destination channel doesn't match the counterparty's channel"
+        );
+
+        ConnectionEnd.Data storage connection = connections[channel.connection_hops[0]];
+
+        bytes32 packetCommitmentKey = checkPacketCommitment(msg_.packet);
+        {
+            string[] memory counterpartyHops = new string[](1);
+            counterpartyHops[0] = connection.counterparty.connection_id;
+            Channel.Data memory expectedChannel = Channel.Data({
+                state: Channel.State.STATE_CLOSED,
+                ordering: channel.ordering,
+                counterparty: ChannelCounterparty.Data({
+                    port_id: msg_.packet.source_port,
+                    channel_id: msg_.packet.source_channel
+                }),
+                connection_hops: counterpartyHops,
+                version: channel.version
+            });
+            require(
+                verifyChannelState(
+                    connection,
+                    msg_.proofHeight,
+                    msg_.proofClose,
+                    IBCCommitment.channelPath(msg_.packet.destination_port, msg_.packet.destination_channel),
+                    Channel.encode(expectedChannel)
+                ),
+                "failed to verify channel state"
+            );
+        }
+        verifyPacketNotReceived(
+            connection, channel.ordering, msg_.packet, msg_.proofHeight, msg_.proof, msg_.nextSequenceRecv
+        );
+
+        delete commitments[packetCommitmentKey];
+        if (channel.ordering == Channel.Order.ORDER_ORDERED) {
+            closeChannel(channel, msg_.packet.source_port, msg_.packet.source_channel);
+        }
+    }
+
     function hashString(string memory s) private pure returns (bytes32) {
         return keccak256(abi.encodePacked(s));
     }
 
+    /**
+     * @dev checkPacketCommitment checks that the commitment of the packet sent on the source channel exists and
+     * returns its key.
+     */
+    function checkPacketCommitment(Packet.Data calldata packet) private view returns (bytes32 packetCommitmentKey) {
+        packetCommitmentKey =
+            IBCCommitment.packetCommitmentKey(packet.source_port, packet.source_channel, packet.sequence);
+        bytes32 packetCommitment = commitments[packetCommitmentKey];
+        require(packetCommitment != bytes32(0), "packet commitment not found");
+        require(
+            packetCommitment
+                == keccak256(
+                    abi.encodePacked(
+                        sha256(
+                            abi.encodePacked(
+                                packet.timeout_timestamp,
+                                packet.timeout_height.revision_number,
+                                packet.timeout_height.revision_height,
+                                sha256(packet.data)
+                            )
+                        )
+                    )
+                ),
+            "commitment bytes are not equal"
+        );
+    }
+
+    /**
+     * @dev verifyPacketNotReceived verifies that the counterparty channel has not received the packet. The proof is
+     * the non-membership proof of the packet receipt for UNORDERED channels, and the membership proof of the next
+     * receive sequence for ORDERED channels.
+     */
+    function verifyPacketNotReceived(
+        ConnectionEnd.Data storage connection,
+        Channel.Order ordering,
+        Packet.Data calldata packet,
+        Height.Data calldata proofHeight,
+        bytes calldata proof,
+        uint64 nextSequenceRecv
+    ) private {
+        if (ordering == Channel.Order.ORDER_UNORDERED) {
+            require(
+                verifyPacketReceiptAbsence(
+                    connection,
+                    proofHeight,
+                    proof,
+                    IBCCommitment.packetReceiptCommitmentPath(
+                        packet.destination_port, packet.destination_channel, packet.sequence
+                    )
+                ),
+                "failed to verify packet receipt absence"
+            );
+        } else if (ordering == Channel.Order.ORDER_ORDERED) {
+            require(nextSequenceRecv <= packet.sequence, "packet sequence < next receive sequence");
+            require(
+                verifyNextSequenceRecv(
+                    connection,
+                    proofHeight,
+                    proof,
+                    IBCCommitment.nextSequenceRecvCommitmentPath(packet.destination_port, packet.destination_channel),
+                    nextSequenceRecv
+                ),
+                "failed to verify next sequence receive"
+            );
+        } else {
+            revert("unknown ordering type");
+        }
+    }
+
+    function closeChannel(Channel.Data storage channel, string calldata portId, string calldata channelId) private {
+        channel.state = Channel.State.STATE_CLOSED;
+        commitments[IBCCommitment.channelCommitmentKey(portId, channelId)] = keccak256(Channel.encode(channel));
+    }
+
     /* Verification functions */
 
     function verifyPacketCommitment(
@@ -266,6 +431,61 @@ contract IBCPacket is IBCStore, IIBCPacket {
         );
     }
 
+    function verifyNextSequenceRecv(
+        ConnectionEnd.Data storage connection,
+        Height.Data calldata height,
+        bytes calldata proof,
+        bytes memory path,
+        uint64 nextSequenceRecv
+    ) private returns (bool) {
+        return checkAndGetClient(connection.client_id).verifyMembership(
+            connection.client_id,
+            height,
+            connection.delay_period,
+            calcBlockDelay(connection.delay_period),
+            proof,
+            connection.counterparty.prefix.key_prefix,
+            path,
+            abi.encodePacked(nextSequenceRecv)
+        );
+    }
+
+    function verifyPacketReceiptAbsence(
+        ConnectionEnd.Data storage connection,
+        Height.Data calldata height,
+        bytes calldata proof,
+        bytes memory path
+    ) private returns (bool) {
+        return checkAndGetClient(connection.client_id).verifyNonMembership(
+            connection.client_id,
+            height,
+            connection.delay_period,
+            calcBlockDelay(connection.delay_period),
+            proof,
+            connection.counterparty.prefix.key_prefix,
+            path
+        );
+    }
+
+    function verifyChannelState(
+        ConnectionEnd.Data storage connection,
+        Height.Data calldata height,
+        bytes calldata proof,
+        bytes memory path,
+        bytes memory channelBytes
+    ) private returns (bool) {
+        return checkAndGetClient(connection.client_id).verifyMembership(
+            connection.client_id,
+            height,
+            0,
+            0,
+            proof,
+            connection.counterparty.prefix.key_prefix,
+            path,
+            channelBytes
+        );
+    }
+
     /* Internal functions */
 
     function calcBlockDelay(uint64 timeDelay) private view returns (uint64) {
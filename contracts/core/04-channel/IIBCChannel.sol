@@ -76,4 +76,19 @@ interface IIBCPacket {
      * It will also increment NextSequenceAck in case of ORDERED channels.
      */
     function acknowledgePacket(IBCMsgs.MsgPacketAcknowledgement calldata msg_) external;
+
+    /**
+     * @dev timeoutPacket is called by a module in order to process a packet that has timed out on the counterparty
+     * chain. The packet must not have been received, which is proven by the absence of the packet receipt for
+     * UNORDERED channels and by the next receive sequence for ORDERED channels. An ORDERED channel is closed after
+     * the timeout.
+     */
+    function timeoutPacket(IBCMsgs.MsgTimeoutPacket calldata msg_) external;
+
+    /**
+     * @dev timeoutOnClose is called by a module in order to process a packet that can no longer be received because
+     * the counterparty channel has been closed, which is proven instead of the timeout. The packet must not have been
+     * received as in timeoutPacket. An ORDERED channel is closed after the timeout.
+     */
+    function timeoutOnClose(IBCMsgs.MsgTimeoutOnClose calldata msg_) external;
 }
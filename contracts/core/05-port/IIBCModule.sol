@@ -63,4 +63,8 @@ interface IIBCModule {
     function onRecvPacket(Packet.Data calldata, address relayer) external returns (bytes memory);
 
     function onAcknowledgementPacket(Packet.Data calldata, bytes calldata acknowledgement, address relayer) external;
+
+    // OnTimeoutPacket is called when the packet has timed out or the counterparty channel has been closed before the
+    // packet is received. An ORDERED channel is closed by the timeout.
+    function onTimeoutPacket(Packet.Data calldata, address relayer) external;
 }
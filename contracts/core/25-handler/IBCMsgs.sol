@@ -120,4 +120,19 @@ library IBCMsgs {
         bytes proof;
         Height.Data proofHeight;
     }
+
+    struct MsgTimeoutPacket {
+        Packet.Data packet;
+        bytes proof;
+        Height.Data proofHeight;
+        uint64 nextSequenceRecv;
+    }
+
+    struct MsgTimeoutOnClose {
+        Packet.Data packet;
+        bytes proof;
+        bytes proofClose;
+        Height.Data proofHeight;
+        uint64 nextSequenceRecv;
+    }
 }
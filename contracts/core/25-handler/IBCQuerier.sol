@@ -67,6 +67,14 @@ abstract contract IBCQuerier is IBCStore {
         return nextSequenceSends[portId][channelId];
     }
 
+    function getNextSequenceRecv(string calldata portId, string calldata channelId) external view returns (uint64) {
+        return nextSequenceRecvs[portId][channelId];
+    }
+
+    function getNextSequenceAck(string calldata portId, string calldata channelId) external view returns (uint64) {
+        return nextSequenceAcks[portId][channelId];
+    }
+
     function getExpectedTimePerBlock() external view returns (uint64) {
         return expectedTimePerBlock;
     }
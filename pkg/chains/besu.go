@@ -79,13 +79,19 @@ func (h ParsedHeader) ValidateAndGetCommitSeals() ([][]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	vals, err := RecoverCommitterAddressesVals(crypto.Keccak256(header), h.Seals)
+	return validateAndGetCommitSeals(header, h.Validators, h.Seals)
+}
+
+// validateAndGetCommitSeals recovers the signers of the seals over the sealing header and returns the seals
+// ordered by the validators. A seal of a validator who did not sign the header is nil.
+func validateAndGetCommitSeals(sealingHeader []byte, validators []common.Address, seals [][]byte) ([][]byte, error) {
+	vals, err := RecoverCommitterAddressesVals(crypto.Keccak256(sealingHeader), seals)
 	if err != nil {
 		return nil, err
 	}
 	var newSeals [][]byte
 	count := 0
-	for _, val := range h.Validators {
+	for _, val := range validators {
 		if seal, ok := vals[val]; ok {
 			count++
 			newSeals = append(newSeals, seal)
@@ -93,7 +99,7 @@ func (h ParsedHeader) ValidateAndGetCommitSeals() ([][]byte, error) {
 			newSeals = append(newSeals, nil)
 		}
 	}
-	if threshold := len(h.Validators) * 2 / 3; count > threshold {
+	if threshold := len(validators) * 2 / 3; count > threshold {
 		return newSeals, nil
 	} else {
 		return nil, fmt.Errorf("insufficient voting: %v > %v", count, threshold)
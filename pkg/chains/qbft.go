@@ -0,0 +1,85 @@
+package chains
+
+import (
+	"bytes"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ParsedQBFTHeader is a header of Besu QBFT consensus, whose extra data is
+// [32 bytes Vanity, List<Validators>, Vote, Round number, List<Commit Seals>].
+//
+// Unlike IBFT 2.0, an absent vote is an empty list, the round number is an integer scalar and
+// the sealing and chain headers keep the commit seals as an empty list.
+type ParsedQBFTHeader struct {
+	Base *gethtypes.Header
+
+	Vanity     [32]byte
+	Validators []common.Address
+	// Vote is the raw RLP of the vote to keep its encoding
+	Vote  rlp.RawValue
+	Round uint32
+	Seals [][]byte
+}
+
+func ParseQBFTHeader(header *gethtypes.Header) (*ParsedQBFTHeader, error) {
+	parsed := ParsedQBFTHeader{Base: header}
+
+	r := bytes.NewReader(header.Extra)
+	stream := rlp.NewStream(r, uint64(len(header.Extra)))
+	if _, err := stream.List(); err != nil {
+		return nil, err
+	}
+	if err := stream.Decode(&parsed.Vanity); err != nil {
+		return nil, err
+	}
+	if err := stream.Decode(&parsed.Validators); err != nil {
+		return nil, err
+	}
+	if err := stream.Decode(&parsed.Vote); err != nil {
+		return nil, err
+	}
+	if err := stream.Decode(&parsed.Round); err != nil {
+		return nil, err
+	}
+	if err := stream.Decode(&parsed.Seals); err != nil {
+		return nil, err
+	}
+	if err := stream.ListEnd(); err != nil {
+		return nil, err
+	}
+
+	return &parsed, nil
+}
+
+// GetSealingHeaderBytes returns the RLP of the header that the commit seals sign.
+func (h ParsedQBFTHeader) GetSealingHeaderBytes() ([]byte, error) {
+	return h.encodeHeader(h.Round)
+}
+
+// GetChainHeaderBytes returns the RLP of the header whose hash is the block hash.
+func (h ParsedQBFTHeader) GetChainHeaderBytes() ([]byte, error) {
+	return h.encodeHeader(0)
+}
+
+func (h ParsedQBFTHeader) encodeHeader(round uint32) ([]byte, error) {
+	newHeader := *h.Base
+	extra, err := rlp.EncodeToBytes([]interface{}{
+		h.Vanity, h.Validators, h.Vote, round, []interface{}{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	newHeader.Extra = extra
+	return rlp.EncodeToBytes(&newHeader)
+}
+
+func (h ParsedQBFTHeader) ValidateAndGetCommitSeals() ([][]byte, error) {
+	header, err := h.GetSealingHeaderBytes()
+	if err != nil {
+		return nil, err
+	}
+	return validateAndGetCommitSeals(header, h.Validators, h.Seals)
+}
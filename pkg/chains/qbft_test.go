@@ -0,0 +1,78 @@
+package chains
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQBFTHeader(t *testing.T) {
+	var keys []*ecdsa.PrivateKey
+	var validators []common.Address
+	for i := 0; i < 4; i++ {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys = append(keys, key)
+		validators = append(validators, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	vanity := [32]byte{1}
+	vote := []interface{}{}
+	var round uint32 = 2
+
+	header := &gethtypes.Header{
+		Number:     big.NewInt(100),
+		Difficulty: big.NewInt(1),
+		Time:       1000,
+	}
+	encodeExtra := func(round uint32, seals [][]byte) []byte {
+		bz, err := rlp.EncodeToBytes([]interface{}{vanity, validators, vote, round, seals})
+		require.NoError(t, err)
+		return bz
+	}
+	sealingHeader := *header
+	sealingHeader.Extra = encodeExtra(round, [][]byte{})
+	chainHeader := *header
+	chainHeader.Extra = encodeExtra(0, [][]byte{})
+
+	// the seals are signed by 3 of 4 validators in the reverse order
+	var seals [][]byte
+	for i := len(keys) - 1; i > 0; i-- {
+		seal, err := crypto.Sign(sealingHeader.Hash().Bytes(), keys[i])
+		require.NoError(t, err)
+		seals = append(seals, seal)
+	}
+	header.Extra = encodeExtra(round, seals)
+
+	parsed, err := ParseQBFTHeader(header)
+	require.NoError(t, err)
+	require.Equal(t, validators, parsed.Validators)
+	require.Equal(t, round, parsed.Round)
+
+	bz, err := parsed.GetSealingHeaderBytes()
+	require.NoError(t, err)
+	require.Equal(t, sealingHeader.Hash(), crypto.Keccak256Hash(bz))
+	bz, err = parsed.GetChainHeaderBytes()
+	require.NoError(t, err)
+	require.Equal(t, chainHeader.Hash(), crypto.Keccak256Hash(bz))
+
+	commitSeals, err := parsed.ValidateAndGetCommitSeals()
+	require.NoError(t, err)
+	require.Len(t, commitSeals, len(validators))
+	require.Nil(t, commitSeals[0])
+	for i := 1; i < len(validators); i++ {
+		require.Equal(t, seals[len(keys)-1-i], commitSeals[i])
+	}
+
+	// 2 of 4 validators are insufficient
+	header.Extra = encodeExtra(round, seals[:2])
+	parsed, err = ParseQBFTHeader(header)
+	require.NoError(t, err)
+	_, err = parsed.ValidateAndGetCommitSeals()
+	require.Error(t, err)
+}
@@ -2,13 +2,16 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"math/big"
+	"strings"
 	"time"
 
 	"github.com/avast/retry-go"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -23,7 +26,15 @@ type ETHClient struct {
 type Option func(*option)
 
 type option struct {
-	retryOpts []retry.Option
+	retryOpts          []retry.Option
+	resubscribeBackoff time.Duration
+	revertRecovery     RevertRecovery
+
+	gasLimit              uint64
+	gasEstimateMultiplier float64
+	feeStrategy           FeeStrategy
+	txReplacement         *ReplacementPolicy
+	dropConfirmations     int
 }
 
 func DefaultOption() *option {
@@ -32,6 +43,11 @@ func DefaultOption() *option {
 			retry.Delay(1 * time.Second),
 			retry.Attempts(10),
 		},
+		resubscribeBackoff: 30 * time.Second,
+		revertRecovery:     RevertRecoveryCall,
+
+		gasEstimateMultiplier: 1.2,
+		dropConfirmations:     DefaultDropConfirmations,
 	}
 }
 
@@ -41,11 +57,74 @@ func WithRetryOption(rops ...retry.Option) Option {
 	}
 }
 
+// WithResubscribeBackoff sets the maximum interval between the attempts to resubscribe a failed subscription.
+func WithResubscribeBackoff(backoffMax time.Duration) Option {
+	return func(opt *option) {
+		opt.resubscribeBackoff = backoffMax
+	}
+}
+
+// WithRevertRecovery sets how the revert reason of a failed transaction is recovered if the receipt does not have it.
+func WithRevertRecovery(recovery RevertRecovery) Option {
+	return func(opt *option) {
+		opt.revertRecovery = recovery
+	}
+}
+
+// WithGasLimit sets the fixed gas limit of the transactions. The gas of each transaction is estimated if it is zero.
+func WithGasLimit(gasLimit uint64) Option {
+	return func(opt *option) {
+		opt.gasLimit = gasLimit
+	}
+}
+
+// WithGasEstimateMultiplier sets the safety multiplier of the estimated gas, e.g. 1.2 adds a margin of 20%.
+func WithGasEstimateMultiplier(multiplier float64) Option {
+	return func(opt *option) {
+		opt.gasEstimateMultiplier = multiplier
+	}
+}
+
+// WithFeeStrategy sets the strategy that decides the fees of the transactions. The backend decides them if it is nil.
+func WithFeeStrategy(strategy FeeStrategy) Option {
+	return func(opt *option) {
+		opt.feeStrategy = strategy
+	}
+}
+
+// WithTxReplacement enables WaitForReceiptAndReplace to replace the transactions that are not mined in time.
+func WithTxReplacement(policy ReplacementPolicy) Option {
+	return func(opt *option) {
+		opt.txReplacement = &policy
+	}
+}
+
+// WithDropConfirmations sets the number of the consecutive polls in which the node knows neither the receipt nor
+// the transaction before the transaction is declared dropped.
+func WithDropConfirmations(n int) Option {
+	return func(opt *option) {
+		opt.dropConfirmations = n
+	}
+}
+
+// NewETHClient connects to the endpoint, whose transport is selected by the scheme: http(s)://, ws(s):// or
+// a path to an IPC socket. Only the websocket and IPC endpoints support subscriptions.
 func NewETHClient(endpoint string, opts ...Option) (*ETHClient, error) {
-	rpcClient, err := rpc.DialHTTP(endpoint)
+	return DialETHClient(context.Background(), endpoint, opts...)
+}
+
+// DialETHClient is the same as NewETHClient except that the context is used to establish the connection.
+func DialETHClient(ctx context.Context, endpoint string, opts ...Option) (*ETHClient, error) {
+	rpcClient, err := rpc.DialContext(ctx, endpoint)
 	if err != nil {
 		return nil, err
 	}
+	return NewETHClientFromRPCClient(rpcClient, opts...), nil
+}
+
+// NewETHClientFromRPCClient returns an ETHClient that uses an already connected rpc client.
+// This is useful for the in-process rpc client of a go-ethereum node.
+func NewETHClientFromRPCClient(rpcClient *rpc.Client, opts ...Option) *ETHClient {
 	opt := DefaultOption()
 	for _, o := range opts {
 		o(opt)
@@ -54,31 +133,48 @@ func NewETHClient(endpoint string, opts ...Option) (*ETHClient, error) {
 		rpcClient: rpcClient,
 		Client:    ethclient.NewClient(rpcClient),
 		option:    *opt,
-	}, nil
+	}
 }
 
 func (cl *ETHClient) GetTransactionReceipt(ctx context.Context, txHash common.Hash) (rc *gethtypes.Receipt, recoverable bool, err error) {
 	var r *Receipt
 	if err := cl.rpcClient.CallContext(ctx, &r, "eth_getTransactionReceipt", txHash); err != nil {
-		return &r.Receipt, true, err
+		return nil, true, err
 	}
 	if r == nil {
 		return nil, true, ethereum.NotFound
 	} else if r.Status == gethtypes.ReceiptStatusSuccessful {
 		return &r.Receipt, false, nil
 	} else if r.HasRevertReason() {
-		reason, err := r.GetRevertReason()
-		return &r.Receipt, false, fmt.Errorf("revert: %v(parse-err=%v)", reason, err)
+		return &r.Receipt, false, r.GetRevertError()
+	} else if data, err := cl.RecoverRevertData(ctx, &r.Receipt); err != nil {
+		return &r.Receipt, false, fmt.Errorf("failed to execute a transaction: %v(recovery-err=%v)", r, err)
+	} else if len(data) > 0 {
+		return &r.Receipt, false, DecodeRevert(data)
 	} else {
 		return &r.Receipt, false, fmt.Errorf("failed to execute a transaction: %v", r)
 	}
 }
 
 func (cl *ETHClient) WaitForReceiptAndGet(ctx context.Context, tx *gethtypes.Transaction) (*gethtypes.Receipt, error) {
-	var receipt *gethtypes.Receipt
+	var (
+		receipt  *gethtypes.Receipt
+		dropErr  error
+		notFound int
+	)
 	err := retry.Do(
 		func() error {
 			rc, recoverable, err := cl.GetTransactionReceipt(ctx, tx.Hash())
+			if errors.Is(err, ethereum.NotFound) {
+				// the transaction that the node does not know will never be mined, but a node behind a load balancer
+				// may not know a transaction that has just been sent, so it must be unknown in consecutive polls
+				if _, _, txErr := cl.TransactionByHash(ctx, tx.Hash()); !errors.Is(txErr, ethereum.NotFound) {
+					notFound = 0
+				} else if notFound++; notFound >= cl.option.dropConfirmations {
+					dropErr = fmt.Errorf("%w: %v", ErrTxDropped, tx.Hash())
+					return retry.Unrecoverable(dropErr)
+				}
+			}
 			if err != nil {
 				if recoverable {
 					return err
@@ -91,7 +187,10 @@ func (cl *ETHClient) WaitForReceiptAndGet(ctx context.Context, tx *gethtypes.Tra
 		},
 		cl.option.retryOpts...,
 	)
-	if err != nil {
+	if dropErr != nil {
+		// return the error as is so that callers can match ErrTxDropped
+		return nil, dropErr
+	} else if err != nil {
 		return nil, err
 	}
 	return receipt, nil
@@ -102,6 +201,22 @@ type Receipt struct {
 	RevertReason []byte `json:"revertReason,omitempty"`
 }
 
+// UnmarshalJSON decodes the receipt and the revertReason field of Besu. It is needed because the promoted
+// UnmarshalJSON of gethtypes.Receipt ignores the fields that are not in the standard receipt.
+func (rc *Receipt) UnmarshalJSON(input []byte) error {
+	if err := rc.Receipt.UnmarshalJSON(input); err != nil {
+		return err
+	}
+	var ext struct {
+		RevertReason hexutil.Bytes `json:"revertReason"`
+	}
+	if err := json.Unmarshal(input, &ext); err != nil {
+		return err
+	}
+	rc.RevertReason = ext.RevertReason
+	return nil
+}
+
 func (rc Receipt) HasRevertReason() bool {
 	return len(rc.RevertReason) > 0
 }
@@ -110,19 +225,23 @@ func (rc Receipt) GetRevertReason() (string, error) {
 	return parseRevertReason(rc.RevertReason)
 }
 
-// A format of revertReason is:
-// 4byte: Function selector for Error(string)
-// 32byte: Data offset
-// 32byte: String length
-// Remains: String Data
+// GetRevertError decodes the revert reason with the ABIs of the bundled contract bindings.
+func (rc Receipt) GetRevertError() *RevertError {
+	return DecodeRevert(rc.RevertReason)
+}
+
+// parseRevertReason returns the message of `Error(string)`, or the description of a panic or a custom error.
 func parseRevertReason(bz []byte) (string, error) {
-	if l := len(bz); l == 0 {
+	if len(bz) == 0 {
 		return "", nil
-	} else if l < 68 {
-		return "", fmt.Errorf("invalid length")
 	}
-
-	size := &big.Int{}
-	size.SetBytes(bz[36:68])
-	return string(bz[68 : 68+size.Int64()]), nil
+	e := DecodeRevert(bz)
+	switch e.Name {
+	case "":
+		return "", fmt.Errorf("failed to decode the revert reason: %x", bz)
+	case RevertErrorName:
+		return e.Reason, nil
+	default:
+		return strings.TrimPrefix(e.Error(), "revert: "), nil
+	}
 }
@@ -1,10 +1,13 @@
 package client
 
 import (
+	"context"
 	"encoding/hex"
 	"strings"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
 )
 
@@ -33,3 +36,20 @@ func hexToBytes(s string) []byte {
 	}
 	return reason
 }
+
+// debugAPI is implemented by the test APIs that also serve the debug namespace.
+type debugAPI interface {
+	TraceTransaction(ctx context.Context, hash common.Hash, config map[string]interface{}) (map[string]interface{}, error)
+}
+
+// newTestClient returns a client of an in-process server that serves the api in the eth namespace, and also in
+// the debug namespace if it implements debugAPI. The server is stopped when the test finishes.
+func newTestClient(t *testing.T, api interface{}, opts ...Option) *ETHClient {
+	server := rpc.NewServer()
+	t.Cleanup(server.Stop)
+	require.NoError(t, server.RegisterName("eth", api))
+	if _, ok := api.(debugAPI); ok {
+		require.NoError(t, server.RegisterName("debug", api))
+	}
+	return NewETHClientFromRPCClient(rpc.DialInProc(server), opts...)
+}
@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// FeeStrategy sets the fee fields of the options of a transaction.
+type FeeStrategy interface {
+	SetFees(ctx context.Context, cl *ETHClient, opts *bind.TransactOpts) error
+}
+
+// LegacyFeeStrategy sets the gas price of a legacy transaction.
+// A zero GasPrice is suitable for the free gas networks of Besu.
+type LegacyFeeStrategy struct {
+	// GasPrice is the fixed gas price. The price suggested by eth_gasPrice is used if it is nil.
+	GasPrice *big.Int
+	// MaxGasPrice caps the gas price if it is not nil
+	MaxGasPrice *big.Int
+}
+
+var _ FeeStrategy = (*LegacyFeeStrategy)(nil)
+
+func (s *LegacyFeeStrategy) SetFees(ctx context.Context, cl *ETHClient, opts *bind.TransactOpts) error {
+	price := s.GasPrice
+	if price == nil {
+		var err error
+		if price, err = cl.SuggestGasPrice(ctx); err != nil {
+			return err
+		}
+	}
+	opts.GasPrice = minBig(new(big.Int).Set(price), s.MaxGasPrice)
+	opts.GasFeeCap, opts.GasTipCap = nil, nil
+	return nil
+}
+
+// DynamicFeeStrategy sets the fees of an EIP-1559 transaction from the priority fees and the base fee
+// returned by eth_feeHistory.
+type DynamicFeeStrategy struct {
+	// BlockCount is the number of the recent blocks whose priority fees are sampled
+	BlockCount uint64
+	// RewardPercentile is the percentile of the priority fees paid in each block
+	RewardPercentile float64
+	// BaseFeeMultiplier is multiplied by the base fee of the next block so that the transaction remains
+	// includable while the base fee rises
+	BaseFeeMultiplier float64
+	// MaxFeePerGas caps the fee cap if it is not nil
+	MaxFeePerGas *big.Int
+}
+
+var _ FeeStrategy = (*DynamicFeeStrategy)(nil)
+
+func DefaultDynamicFeeStrategy() *DynamicFeeStrategy {
+	return &DynamicFeeStrategy{
+		BlockCount:        20,
+		RewardPercentile:  50,
+		BaseFeeMultiplier: 2,
+	}
+}
+
+func (s *DynamicFeeStrategy) SetFees(ctx context.Context, cl *ETHClient, opts *bind.TransactOpts) error {
+	history, err := cl.FeeHistory(ctx, s.BlockCount, nil, []float64{s.RewardPercentile})
+	if err != nil {
+		return err
+	}
+	if len(history.BaseFee) == 0 || history.BaseFee[len(history.BaseFee)-1] == nil {
+		return errors.New("the chain does not support EIP-1559")
+	}
+	tip, err := medianReward(history)
+	if err != nil {
+		return err
+	} else if tip == nil {
+		// no blocks are sampled
+		if tip, err = cl.SuggestGasTipCap(ctx); err != nil {
+			return err
+		}
+	}
+	// the last base fee is the one of the next block
+	baseFee := new(big.Float).SetInt(history.BaseFee[len(history.BaseFee)-1])
+	feeCap, _ := baseFee.Mul(baseFee, big.NewFloat(s.BaseFeeMultiplier)).Int(nil)
+	feeCap = minBig(feeCap.Add(feeCap, tip), s.MaxFeePerGas)
+	opts.GasFeeCap = feeCap
+	opts.GasTipCap = minBig(tip, feeCap)
+	opts.GasPrice = nil
+	return nil
+}
+
+// medianReward returns the median of the sampled priority fees of the blocks, or nil if there are no samples.
+func medianReward(history *ethereum.FeeHistory) (*big.Int, error) {
+	var rewards []*big.Int
+	for _, reward := range history.Reward {
+		if len(reward) != 1 {
+			return nil, errors.New("unexpected number of rewards in the fee history")
+		}
+		rewards = append(rewards, reward[0])
+	}
+	if len(rewards) == 0 {
+		return nil, nil
+	}
+	sort.Slice(rewards, func(i, j int) bool { return rewards[i].Cmp(rewards[j]) < 0 })
+	return new(big.Int).Set(rewards[len(rewards)/2]), nil
+}
+
+// minBig returns x capped by max. max is ignored if it is nil.
+func minBig(x, max *big.Int) *big.Int {
+	if max != nil && x.Cmp(max) > 0 {
+		return new(big.Int).Set(max)
+	}
+	return x
+}
+
+// SetTxFees sets the gas limit and the fees of the options with the configuration of the client.
+// The gas is estimated when the transaction is sent if the gas limit is not configured.
+func (cl *ETHClient) SetTxFees(ctx context.Context, opts *bind.TransactOpts) error {
+	opts.GasLimit = cl.option.gasLimit
+	if cl.option.feeStrategy == nil {
+		// the backend decides the fees
+		return nil
+	}
+	return cl.option.feeStrategy.SetFees(ctx, cl, opts)
+}
+
+// EstimateGas estimates the gas of the call with the safety margin configured by WithGasEstimateMultiplier.
+// It is used by the contract bindings when the gas limit of a transaction is not set.
+func (cl *ETHClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	gas, err := cl.Client.EstimateGas(ctx, msg)
+	if err != nil {
+		return 0, err
+	}
+	if cl.option.gasEstimateMultiplier > 1 {
+		gas = uint64(float64(gas) * cl.option.gasEstimateMultiplier)
+	}
+	return gas, nil
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// feeEthAPI serves the fee related methods with the fixed values.
+type feeEthAPI struct{}
+
+type feeHistoryResult struct {
+	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
+}
+
+func (feeEthAPI) GasPrice(ctx context.Context) *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(30))
+}
+
+func (feeEthAPI) FeeHistory(ctx context.Context, blockCount hexutil.Uint64, lastBlock rpc.BlockNumber, percentiles []float64) *feeHistoryResult {
+	b := func(v int64) *hexutil.Big { return (*hexutil.Big)(big.NewInt(v)) }
+	return &feeHistoryResult{
+		OldestBlock:  b(1),
+		Reward:       [][]*hexutil.Big{{b(3)}, {b(1)}, {b(2)}},
+		BaseFee:      []*hexutil.Big{b(100), b(100), b(100), b(110)},
+		GasUsedRatio: []float64{0.5, 0.5, 0.5},
+	}
+}
+
+func (feeEthAPI) EstimateGas(ctx context.Context, args map[string]interface{}) hexutil.Uint64 {
+	return 100000
+}
+
+func TestFeeStrategy(t *testing.T) {
+	ctx := context.Background()
+
+	// the backend decides the fees by default
+	opts := &bind.TransactOpts{}
+	require.NoError(t, newTestClient(t, feeEthAPI{}).SetTxFees(ctx, opts))
+	require.Zero(t, opts.GasLimit)
+	require.Nil(t, opts.GasPrice)
+	require.Nil(t, opts.GasFeeCap)
+
+	opts = &bind.TransactOpts{}
+	require.NoError(t, newTestClient(t, feeEthAPI{}, WithGasLimit(1000000), WithFeeStrategy(&LegacyFeeStrategy{})).SetTxFees(ctx, opts))
+	require.Equal(t, uint64(1000000), opts.GasLimit)
+	require.Equal(t, big.NewInt(30), opts.GasPrice)
+
+	// free gas
+	opts = &bind.TransactOpts{}
+	require.NoError(t, newTestClient(t, feeEthAPI{}, WithFeeStrategy(&LegacyFeeStrategy{GasPrice: big.NewInt(0)})).SetTxFees(ctx, opts))
+	require.Equal(t, big.NewInt(0), opts.GasPrice)
+
+	opts = &bind.TransactOpts{}
+	require.NoError(t, newTestClient(t, feeEthAPI{}, WithFeeStrategy(&LegacyFeeStrategy{MaxGasPrice: big.NewInt(20)})).SetTxFees(ctx, opts))
+	require.Equal(t, big.NewInt(20), opts.GasPrice)
+
+	// the fee cap is twice the next base fee plus the median of the priority fees
+	opts = &bind.TransactOpts{}
+	require.NoError(t, newTestClient(t, feeEthAPI{}, WithFeeStrategy(DefaultDynamicFeeStrategy())).SetTxFees(ctx, opts))
+	require.Nil(t, opts.GasPrice)
+	require.Equal(t, big.NewInt(2), opts.GasTipCap)
+	require.Equal(t, big.NewInt(222), opts.GasFeeCap)
+
+	strategy := DefaultDynamicFeeStrategy()
+	strategy.MaxFeePerGas = big.NewInt(150)
+	opts = &bind.TransactOpts{}
+	require.NoError(t, newTestClient(t, feeEthAPI{}, WithFeeStrategy(strategy)).SetTxFees(ctx, opts))
+	require.Equal(t, big.NewInt(150), opts.GasFeeCap)
+}
+
+func TestEstimateGas(t *testing.T) {
+	ctx := context.Background()
+	gas, err := newTestClient(t, feeEthAPI{}).EstimateGas(ctx, ethereum.CallMsg{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(120000), gas)
+	gas, err = newTestClient(t, feeEthAPI{}, WithGasEstimateMultiplier(1)).EstimateGas(ctx, ethereum.CallMsg{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(100000), gas)
+}
@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// NonceBackend is the part of a client that the NonceManager needs to sync the nonces of accounts.
+type NonceBackend interface {
+	// PendingNonceAt returns the next nonce of the account including the transactions in the txpool.
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	// NonceAt returns the nonce of the account at the given block. The latest block is used if it is nil.
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+}
+
+// PendingTx is a transaction that is sent but its receipt is not confirmed yet.
+type PendingTx struct {
+	Nonce uint64
+	Hash  common.Hash
+}
+
+// NonceManager assigns the nonces of accounts locally, so that many transactions can be sent without waiting for
+// the preceding ones to be mined. The nonce of an account is synced with the backend when it is used for the
+// first time and after it is marked as stale.
+type NonceManager struct {
+	backend NonceBackend
+
+	mtx      sync.Mutex
+	accounts map[common.Address]*nonceAccount
+}
+
+type nonceAccount struct {
+	// next is the nonce that is assigned to the next transaction
+	next uint64
+	// stale is true if next must be synced with the backend before it is used
+	stale   bool
+	pending map[uint64]common.Hash
+}
+
+func NewNonceManager(backend NonceBackend) *NonceManager {
+	return &NonceManager{
+		backend:  backend,
+		accounts: make(map[common.Address]*nonceAccount),
+	}
+}
+
+func (m *NonceManager) account(account common.Address) *nonceAccount {
+	acc, ok := m.accounts[account]
+	if !ok {
+		acc = &nonceAccount{stale: true, pending: make(map[uint64]common.Hash)}
+		m.accounts[account] = acc
+	}
+	return acc
+}
+
+// Next assigns a nonce to a new transaction of the account.
+// The caller must call Release if the transaction is not sent, or Sent if it is.
+func (m *NonceManager) Next(ctx context.Context, account common.Address) (uint64, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	acc := m.account(account)
+	if acc.stale {
+		if err := m.sync(ctx, account, acc); err != nil {
+			return 0, err
+		}
+	}
+	nonce := acc.next
+	acc.next++
+	return nonce, nil
+}
+
+// Release gives back the nonce of a transaction that was not sent. The nonce is reused by the next transaction
+// if it is the last assigned one. Otherwise, there is a gap in the nonces, so the account is synced again.
+func (m *NonceManager) Release(account common.Address, nonce uint64) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	acc := m.account(account)
+	if !acc.stale && nonce+1 == acc.next {
+		acc.next = nonce
+	} else {
+		acc.stale = true
+	}
+}
+
+// Sent records that the transaction with the nonce is sent.
+func (m *NonceManager) Sent(account common.Address, nonce uint64, txHash common.Hash) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.account(account).pending[nonce] = txHash
+}
+
+// Done records that the transaction with the nonce is mined.
+func (m *NonceManager) Done(account common.Address, nonce uint64) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	delete(m.account(account).pending, nonce)
+}
+
+// Pending returns the transactions of the account that are sent but not mined yet, ordered by nonce.
+func (m *NonceManager) Pending(account common.Address) []PendingTx {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	acc := m.account(account)
+	txs := make([]PendingTx, 0, len(acc.pending))
+	for nonce, hash := range acc.pending {
+		txs = append(txs, PendingTx{Nonce: nonce, Hash: hash})
+	}
+	sort.Slice(txs, func(i, j int) bool { return txs[i].Nonce < txs[j].Nonce })
+	return txs
+}
+
+// Invalidate marks the nonce of the account as stale, so it is synced with the backend when it is used next time.
+func (m *NonceManager) Invalidate(account common.Address) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.account(account).stale = true
+}
+
+// Reset marks the nonces of all accounts as stale.
+func (m *NonceManager) Reset() {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for _, acc := range m.accounts {
+		acc.stale = true
+	}
+}
+
+// Resync syncs the nonce of the account with the backend immediately, e.g. after a "nonce too low" error.
+// The pending transactions whose nonces are already used on chain are forgotten.
+func (m *NonceManager) Resync(ctx context.Context, account common.Address) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.sync(ctx, account, m.account(account))
+}
+
+func (m *NonceManager) sync(ctx context.Context, account common.Address, acc *nonceAccount) error {
+	next, err := m.backend.PendingNonceAt(ctx, account)
+	if err != nil {
+		return err
+	}
+	mined, err := m.backend.NonceAt(ctx, account, nil)
+	if err != nil {
+		return err
+	}
+	for nonce := range acc.pending {
+		if nonce < mined {
+			delete(acc.pending, nonce)
+		}
+	}
+	acc.next = next
+	acc.stale = false
+	return nil
+}
+
+// IsNonceTooLow returns true if the error means that the nonce of a transaction is already used.
+func IsNonceTooLow(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "nonce too low") || strings.Contains(msg, "nonce_too_low")
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeNonceBackend struct {
+	pending uint64
+	mined   uint64
+	calls   int
+}
+
+func (b *fakeNonceBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	b.calls++
+	return b.pending, nil
+}
+
+func (b *fakeNonceBackend) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return b.mined, nil
+}
+
+func TestNonceManager(t *testing.T) {
+	ctx := context.Background()
+	backend := &fakeNonceBackend{pending: 5, mined: 5}
+	m := NewNonceManager(backend)
+	account := common.Address{1}
+
+	// the nonces are assigned locally after the first sync
+	for i := uint64(5); i < 8; i++ {
+		nonce, err := m.Next(ctx, account)
+		require.NoError(t, err)
+		require.Equal(t, i, nonce)
+		m.Sent(account, nonce, common.Hash{byte(nonce)})
+	}
+	require.Equal(t, 1, backend.calls)
+	require.Equal(t, []PendingTx{{5, common.Hash{5}}, {6, common.Hash{6}}, {7, common.Hash{7}}}, m.Pending(account))
+	m.Done(account, 5)
+	require.Len(t, m.Pending(account), 2)
+
+	// the last nonce is reused if its transaction is not sent
+	nonce, err := m.Next(ctx, account)
+	require.NoError(t, err)
+	m.Release(account, nonce)
+	nonce2, err := m.Next(ctx, account)
+	require.NoError(t, err)
+	require.Equal(t, nonce, nonce2)
+
+	// a gap makes the account synced again
+	_, err = m.Next(ctx, account)
+	require.NoError(t, err)
+	m.Release(account, nonce2)
+	backend.pending, backend.mined = 8, 7
+	nonce, err = m.Next(ctx, account)
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), nonce)
+	require.Equal(t, 2, backend.calls)
+	// the mined transaction is forgotten
+	require.Equal(t, []PendingTx{{7, common.Hash{7}}}, m.Pending(account))
+
+	// resync after another sender used the nonces
+	backend.pending, backend.mined = 12, 12
+	require.NoError(t, m.Resync(ctx, account))
+	nonce, err = m.Next(ctx, account)
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), nonce)
+	require.Empty(t, m.Pending(account))
+
+	m.Reset()
+	_, err = m.Next(ctx, account)
+	require.NoError(t, err)
+	require.Equal(t, 4, backend.calls)
+}
+
+func TestIsNonceTooLow(t *testing.T) {
+	require.True(t, IsNonceTooLow(errors.New("nonce too low: address 0x01, tx: 1 state: 2")))
+	require.True(t, IsNonceTooLow(errors.New("Nonce too low")))
+	require.False(t, IsNonceTooLow(errors.New("replacement transaction underpriced")))
+	require.False(t, IsNonceTooLow(nil))
+}
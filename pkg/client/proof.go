@@ -8,7 +8,11 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
 )
 
 type StateProof struct {
@@ -139,3 +143,90 @@ func decodeHexString(s string) ([]byte, error) {
 	}
 	return hex.DecodeString(s)
 }
+
+// Verify verifies the account proof against the state root and the storage proofs of the storage keys
+// against the storage hash. It fails if the proof is inconsistent even if it proves the absence of a slot.
+func (p *StateProof) Verify(stateRoot common.Hash, address common.Address, storageKeys [][]byte) error {
+	if err := p.VerifyAccountProof(stateRoot, address); err != nil {
+		return err
+	}
+	if len(p.StorageProofRLP) != len(storageKeys) {
+		return fmt.Errorf("storage proofs length mismatch: expected=%v actual=%v", len(storageKeys), len(p.StorageProofRLP))
+	}
+	for i, key := range storageKeys {
+		var slot common.Hash
+		if err := slot.UnmarshalText(key); err != nil {
+			return err
+		}
+		if _, _, err := p.VerifyStorageProof(i, slot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VerifyAccountProof verifies AccountProofRLP against the state root and checks that the account has
+// the nonce, balance, storage hash and code hash of the proof.
+func (p *StateProof) VerifyAccountProof(stateRoot common.Hash, address common.Address) error {
+	bz, err := VerifyRLPProof(stateRoot, crypto.Keccak256(address.Bytes()), p.AccountProofRLP)
+	if err != nil {
+		return fmt.Errorf("invalid account proof: address=%v err=%w", address, err)
+	} else if len(bz) == 0 {
+		return fmt.Errorf("account not found: address=%v", address)
+	}
+	var account gethtypes.StateAccount
+	if err := rlp.DecodeBytes(bz, &account); err != nil {
+		return err
+	}
+	if account.Nonce != p.Nonce {
+		return fmt.Errorf("nonce mismatch: expected=%v actual=%v", account.Nonce, p.Nonce)
+	} else if account.Balance.Cmp(&p.Balance) != 0 {
+		return fmt.Errorf("balance mismatch: expected=%v actual=%v", account.Balance, &p.Balance)
+	} else if account.Root != p.StorageHash {
+		return fmt.Errorf("storage hash mismatch: expected=%v actual=%v", account.Root, common.Hash(p.StorageHash))
+	} else if common.BytesToHash(account.CodeHash) != p.CodeHash {
+		return fmt.Errorf("code hash mismatch: expected=%x actual=%x", account.CodeHash, p.CodeHash)
+	}
+	return nil
+}
+
+// VerifyStorageProof verifies StorageProofRLP[index] of the slot against StorageHash. It returns the value
+// of the slot and true, or false if the proof proves that the slot is empty.
+func (p *StateProof) VerifyStorageProof(index int, slot common.Hash) (common.Hash, bool, error) {
+	if index < 0 || index >= len(p.StorageProofRLP) {
+		return common.Hash{}, false, fmt.Errorf("storage proof not found: index=%v", index)
+	}
+	return VerifyStorageProof(p.StorageHash, slot, p.StorageProofRLP[index])
+}
+
+// VerifyStorageProof verifies the storage proof of the slot against the storage root. It returns the value
+// of the slot and true, or false if the proof proves that the slot is empty.
+func VerifyStorageProof(storageRoot common.Hash, slot common.Hash, storageProofRLP []byte) (common.Hash, bool, error) {
+	bz, err := VerifyRLPProof(storageRoot, crypto.Keccak256(slot.Bytes()), storageProofRLP)
+	if err != nil {
+		return common.Hash{}, false, fmt.Errorf("invalid storage proof: slot=%v err=%w", slot, err)
+	} else if len(bz) == 0 {
+		return common.Hash{}, false, nil
+	}
+	var value []byte
+	if err := rlp.DecodeBytes(bz, &value); err != nil {
+		return common.Hash{}, false, err
+	}
+	return common.BytesToHash(value), true, nil
+}
+
+// VerifyRLPProof verifies the Merkle-Patricia proof of the key against the root. The proof is an RLP list of
+// the trie nodes such as AccountProofRLP. It returns nil if the proof proves the absence of the key.
+func VerifyRLPProof(root common.Hash, key []byte, proofRLP []byte) ([]byte, error) {
+	var nodes []rlp.RawValue
+	if err := rlp.DecodeBytes(proofRLP, &nodes); err != nil {
+		return nil, err
+	}
+	db := memorydb.New()
+	for _, node := range nodes {
+		if err := db.Put(crypto.Keccak256(node), node); err != nil {
+			return nil, err
+		}
+	}
+	return trie.VerifyProof(root, key, db)
+}
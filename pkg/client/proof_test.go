@@ -0,0 +1,101 @@
+package client
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateProof(t *testing.T) {
+	address := common.HexToAddress("0xaa43d337145E8930d01cb4E60Abf6595C692921E")
+	slot := common.HexToHash("0x01")
+	emptySlot := common.HexToHash("0x02")
+	value := crypto.Keccak256Hash([]byte("value"))
+
+	storageTrie := newTrie()
+	bz, err := rlp.EncodeToBytes(common.TrimLeftZeroes(value.Bytes()))
+	require.NoError(t, err)
+	require.NoError(t, storageTrie.Update(crypto.Keccak256(slot.Bytes()), bz))
+
+	account := gethtypes.StateAccount{
+		Nonce:    1,
+		Balance:  big.NewInt(100),
+		Root:     storageTrie.Hash(),
+		CodeHash: crypto.Keccak256([]byte("code")),
+	}
+	stateTrie := newTrie()
+	bz, err = rlp.EncodeToBytes(&account)
+	require.NoError(t, err)
+	require.NoError(t, stateTrie.Update(crypto.Keccak256(address.Bytes()), bz))
+
+	proof := StateProof{
+		Balance:         *account.Balance,
+		CodeHash:        common.BytesToHash(account.CodeHash),
+		Nonce:           account.Nonce,
+		StorageHash:     account.Root,
+		AccountProofRLP: prove(t, stateTrie, crypto.Keccak256(address.Bytes())),
+		StorageProofRLP: [][]byte{
+			prove(t, storageTrie, crypto.Keccak256(slot.Bytes())),
+			prove(t, storageTrie, crypto.Keccak256(emptySlot.Bytes())),
+		},
+	}
+	require.NoError(t, proof.Verify(stateTrie.Hash(), address, [][]byte{[]byte(slot.Hex()), []byte(emptySlot.Hex())}))
+
+	// membership
+	v, found, err := proof.VerifyStorageProof(0, slot)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, value, v)
+
+	// non-membership
+	_, found, err = proof.VerifyStorageProof(1, emptySlot)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// another storage root
+	_, _, err = VerifyStorageProof(common.HexToHash("0x01"), slot, proof.StorageProofRLP[0])
+	require.Error(t, err)
+
+	// another state root
+	require.Error(t, proof.VerifyAccountProof(common.HexToHash("0x01"), address))
+	// the account is not found
+	require.Error(t, proof.VerifyAccountProof(stateTrie.Hash(), common.HexToAddress("0x01")))
+
+	// the account fields do not match
+	invalid := proof
+	invalid.Nonce = 2
+	require.Error(t, invalid.VerifyAccountProof(stateTrie.Hash(), address))
+	invalid = proof
+	invalid.StorageHash = common.HexToHash("0x01")
+	require.Error(t, invalid.VerifyAccountProof(stateTrie.Hash(), address))
+}
+
+func newTrie() *trie.Trie {
+	return trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase()))
+}
+
+type proofList []rlp.RawValue
+
+func (l *proofList) Put(key []byte, value []byte) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func (l *proofList) Delete(key []byte) error {
+	panic("not supported")
+}
+
+func prove(t *testing.T, tr *trie.Trie, key []byte) []byte {
+	var nodes proofList
+	require.NoError(t, tr.Prove(key, 0, &nodes))
+	bz, err := rlp.EncodeToBytes(nodes)
+	require.NoError(t, err)
+	return bz
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+var testRevertData = hexToBytes("0x4e487b710000000000000000000000000000000000000000000000000000000000000011")
+
+// failingEthAPI serves a failed transaction whose receipt does not have revertReason like geth.
+type failingEthAPI struct {
+	tx         *gethtypes.Transaction
+	callNumber *rpc.BlockNumber
+}
+
+type callError struct{}
+
+func (callError) Error() string          { return "execution reverted" }
+func (callError) ErrorCode() int         { return 3 }
+func (callError) ErrorData() interface{} { return hexutil.Encode(testRevertData) }
+
+func (api *failingEthAPI) GetTransactionReceipt(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"status":            "0x0",
+		"cumulativeGasUsed": "0x5208",
+		"logsBloom":         gethtypes.Bloom{},
+		"logs":              []interface{}{},
+		"transactionHash":   hash,
+		"gasUsed":           "0x5208",
+		"blockHash":         common.Hash{1},
+		"blockNumber":       "0xa",
+		"transactionIndex":  "0x0",
+	}, nil
+}
+
+func (api *failingEthAPI) GetTransactionByHash(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	fields, err := toMap(api.tx)
+	if err != nil {
+		return nil, err
+	}
+	fields["blockHash"] = common.Hash{1}
+	fields["blockNumber"] = "0xa"
+	fields["transactionIndex"] = "0x0"
+	return fields, nil
+}
+
+func (api *failingEthAPI) Call(ctx context.Context, args map[string]interface{}, number rpc.BlockNumber) (hexutil.Bytes, error) {
+	api.callNumber = &number
+	return nil, callError{}
+}
+
+type traceAPI struct{}
+
+func (traceAPI) TraceTransaction(ctx context.Context, hash common.Hash, config map[string]interface{}) (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"output": hexutil.Encode(testRevertData),
+		"error":  "execution reverted",
+	}, nil
+}
+
+// tracingEthAPI serves the failed transaction and its trace.
+type tracingEthAPI struct {
+	*failingEthAPI
+	traceAPI
+}
+
+func TestRevertRecovery(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	to := common.Address{2}
+	tx, err := gethtypes.SignNewTx(key, gethtypes.LatestSignerForChainID(big.NewInt(1)), &gethtypes.LegacyTx{To: &to, Gas: 100000, GasPrice: big.NewInt(1)})
+	require.NoError(t, err)
+
+	for _, c := range []struct {
+		recovery RevertRecovery
+		debug    bool
+		replayed bool
+	}{
+		{RevertRecoveryCall, false, true},
+		{RevertRecoveryTrace, true, false},
+		// falls back to eth_call if the debug namespace is not served
+		{RevertRecoveryTrace, false, true},
+	} {
+		api := &failingEthAPI{tx: tx}
+		var cl *ETHClient
+		if c.debug {
+			cl = newTestClient(t, &tracingEthAPI{api, traceAPI{}}, WithRevertRecovery(c.recovery))
+		} else {
+			cl = newTestClient(t, api, WithRevertRecovery(c.recovery))
+		}
+
+		_, recoverable, err := cl.GetTransactionReceipt(context.Background(), tx.Hash())
+		require.False(t, recoverable)
+		var revertErr *RevertError
+		require.True(t, errors.As(err, &revertErr), err)
+		require.True(t, revertErr.IsPanic())
+		if c.replayed {
+			// the transaction is replayed on the parent block
+			require.Equal(t, rpc.BlockNumber(9), *api.callNumber)
+		} else {
+			require.Nil(t, api.callNumber)
+		}
+	}
+}
+
+func TestReceiptRevertReason(t *testing.T) {
+	var r Receipt
+	require.NoError(t, r.UnmarshalJSON([]byte(`{"status":"0x0","cumulativeGasUsed":"0x0","logsBloom":"0x`+common.Bytes2Hex(make([]byte, 256))+`","logs":[],"transactionHash":"0x0000000000000000000000000000000000000000000000000000000000000000","gasUsed":"0x0","revertReason":"0x4e487b71"}`)))
+	require.Equal(t, hexToBytes("0x4e487b71"), r.RevertReason)
+}
+
+func toMap(tx *gethtypes.Transaction) (map[string]interface{}, error) {
+	bz, err := tx.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(bz, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,236 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrTxDropped is returned if a transaction is neither mined nor in the txpool, e.g. because it was evicted
+// from the txpool or another transaction with the same nonce was mined.
+var ErrTxDropped = errors.New("transaction is dropped")
+
+// DefaultDropConfirmations is the default number of the consecutive polls in which a transaction is unknown to the
+// node before it is declared dropped.
+const DefaultDropConfirmations = 3
+
+// ReplacementPolicy decides when and how a pending transaction is replaced with a transaction that has
+// the same nonce and higher fees.
+type ReplacementPolicy struct {
+	// Interval is how long a transaction is waited for before it is replaced
+	Interval time.Duration
+	// PollInterval is the interval between the lookups of the receipts
+	PollInterval time.Duration
+	// BumpPercent is the percentage by which the fees are raised. Most nodes require at least 10.
+	BumpPercent int64
+	// MaxReplacements is the number of the replacements after which the transactions are no longer replaced
+	MaxReplacements int
+	// MaxFee caps the gas price or the fee cap of the replacements if it is not nil
+	MaxFee *big.Int
+}
+
+func DefaultReplacementPolicy() ReplacementPolicy {
+	return ReplacementPolicy{
+		Interval:        time.Minute,
+		PollInterval:    time.Second,
+		BumpPercent:     12,
+		MaxReplacements: 5,
+	}
+}
+
+// WaitForReceiptAndReplace waits for the receipt of the transaction like WaitForReceiptAndGet. If the client has
+// the option WithTxReplacement, the transaction is re-signed with the signer and bumped fees whenever it is not
+// mined within the interval of the policy, and the receipt of whichever transaction is mined is returned.
+// The hashes of the transaction and all its replacements are returned in the order they were sent.
+func (cl *ETHClient) WaitForReceiptAndReplace(ctx context.Context, tx *gethtypes.Transaction, signer bind.SignerFn) (*gethtypes.Receipt, []common.Hash, error) {
+	if cl.option.txReplacement == nil {
+		rc, err := cl.WaitForReceiptAndGet(ctx, tx)
+		return rc, []common.Hash{tx.Hash()}, err
+	}
+	w := &replacer{cl: cl, policy: *cl.option.txReplacement, signer: signer, sent: []*gethtypes.Transaction{tx}}
+	rc, err := w.wait(ctx)
+	return rc, w.hashes(), err
+}
+
+type replacer struct {
+	cl     *ETHClient
+	policy ReplacementPolicy
+	signer bind.SignerFn
+	// sent is the transaction and its replacements
+	sent []*gethtypes.Transaction
+	// attempts is the number of the attempts to replace, including the ones rejected by the node
+	attempts int
+	// notFound is the number of the consecutive polls in which none of the sent transactions is known to the node
+	notFound int
+}
+
+func (w *replacer) hashes() []common.Hash {
+	hashes := make([]common.Hash, len(w.sent))
+	for i, tx := range w.sent {
+		hashes[i] = tx.Hash()
+	}
+	return hashes
+}
+
+func (w *replacer) wait(ctx context.Context) (*gethtypes.Receipt, error) {
+	deadline := time.Now().Add(w.policy.Interval)
+	for {
+		rc, found, err := w.receipt(ctx)
+		if found {
+			return rc, err
+		} else if err != nil {
+			return nil, err
+		}
+
+		dropped, err := w.dropped(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if dropped {
+			w.notFound++
+		} else {
+			w.notFound = 0
+		}
+		dropped = w.notFound >= w.cl.option.dropConfirmations
+		if dropped || time.Now().After(deadline) {
+			if w.attempts >= w.policy.MaxReplacements {
+				if dropped {
+					return nil, fmt.Errorf("%w: %v", ErrTxDropped, w.hashes())
+				}
+				return nil, fmt.Errorf("transaction is not mined after %v replacements: %v", w.policy.MaxReplacements, w.hashes())
+			}
+			if err := w.replace(ctx); err != nil {
+				return nil, err
+			}
+			w.notFound = 0
+			deadline = time.Now().Add(w.policy.Interval)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(w.policy.PollInterval):
+		}
+	}
+}
+
+// receipt returns the receipt of the sent transaction that is mined. found is false if none of them is mined yet.
+func (w *replacer) receipt(ctx context.Context) (rc *gethtypes.Receipt, found bool, err error) {
+	for i := len(w.sent) - 1; i >= 0; i-- {
+		rc, recoverable, err := w.cl.GetTransactionReceipt(ctx, w.sent[i].Hash())
+		if err == nil {
+			return rc, true, nil
+		} else if !recoverable {
+			// the transaction is mined but failed
+			return rc, true, err
+		} else if !errors.Is(err, ethereum.NotFound) {
+			return nil, false, err
+		}
+	}
+	return nil, false, nil
+}
+
+// dropped returns true if none of the sent transactions is known to the node.
+func (w *replacer) dropped(ctx context.Context) (bool, error) {
+	for _, tx := range w.sent {
+		if _, _, err := w.cl.TransactionByHash(ctx, tx.Hash()); err == nil {
+			return false, nil
+		} else if !errors.Is(err, ethereum.NotFound) {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// replace sends the latest transaction again with bumped fees. Nothing is sent once the fees are capped.
+func (w *replacer) replace(ctx context.Context) error {
+	w.attempts++
+	last := w.sent[len(w.sent)-1]
+	from, err := gethtypes.Sender(gethtypes.LatestSignerForChainID(last.ChainId()), last)
+	if err != nil {
+		return err
+	}
+	unsigned, err := w.bump(ctx, last)
+	if err != nil {
+		return err
+	}
+	if unsigned.GasFeeCap().Cmp(last.GasFeeCap()) <= 0 {
+		// the fees have reached the cap, so the replacement would be the same transaction
+		return nil
+	}
+	tx, err := w.signer(from, unsigned)
+	if err != nil {
+		return err
+	}
+	if err := w.cl.SendTransaction(ctx, tx); err != nil {
+		if IsNonceTooLow(err) || isReplacementRejected(err) {
+			// one of the sent transactions may have been mined, which is found by the next lookup
+			return nil
+		}
+		return err
+	}
+	w.sent = append(w.sent, tx)
+	return nil
+}
+
+// bump returns the unsigned copy of the transaction whose fees are raised by the percentage of the policy.
+// The fees suggested by the fee strategy of the client are used instead if they are higher.
+func (w *replacer) bump(ctx context.Context, tx *gethtypes.Transaction) (*gethtypes.Transaction, error) {
+	suggested := &bind.TransactOpts{}
+	if w.cl.option.feeStrategy != nil {
+		if err := w.cl.option.feeStrategy.SetFees(ctx, w.cl, suggested); err != nil {
+			return nil, err
+		}
+	}
+	switch tx.Type() {
+	case gethtypes.LegacyTxType:
+		return gethtypes.NewTx(&gethtypes.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: w.bumpFee(tx.GasPrice(), suggested.GasPrice),
+			Gas:      tx.Gas(),
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		}), nil
+	case gethtypes.DynamicFeeTxType:
+		feeCap := w.bumpFee(tx.GasFeeCap(), suggested.GasFeeCap)
+		return gethtypes.NewTx(&gethtypes.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  minBig(w.bumpFee(tx.GasTipCap(), suggested.GasTipCap), feeCap),
+			GasFeeCap:  feeCap,
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported transaction type: %v", tx.Type())
+	}
+}
+
+func (w *replacer) bumpFee(fee, suggested *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(100+w.policy.BumpPercent))
+	// round up so that a small fee is raised at least by one
+	bumped.Add(bumped, big.NewInt(99)).Div(bumped, big.NewInt(100))
+	if suggested != nil && suggested.Cmp(bumped) > 0 {
+		bumped = new(big.Int).Set(suggested)
+	}
+	return minBig(bumped, w.policy.MaxFee)
+}
+
+// isReplacementRejected returns true if the node rejected the replacement because of its fees, or because
+// it already has the same transaction.
+func isReplacementRejected(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "underpriced") || strings.Contains(msg, "already known")
+}
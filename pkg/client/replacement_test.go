@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// txPoolAPI keeps the sent transactions in the pool and mines the one whose gas price reaches minGasPrice.
+type txPoolAPI struct {
+	mtx         sync.Mutex
+	minGasPrice *big.Int
+	pool        map[common.Hash]*gethtypes.Transaction
+	mined       *gethtypes.Transaction
+	// unknownLookups is the number of the first lookups of the transactions that return null, like a node behind
+	// a load balancer that has not received the transactions yet
+	unknownLookups int
+	// lookups is the number of the lookups of the transactions
+	lookups int
+}
+
+func (api *txPoolAPI) SendRawTransaction(ctx context.Context, input hexutil.Bytes) (common.Hash, error) {
+	api.mtx.Lock()
+	defer api.mtx.Unlock()
+	tx := new(gethtypes.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+	api.pool[tx.Hash()] = tx
+	return tx.Hash(), nil
+}
+
+func (api *txPoolAPI) GetTransactionByHash(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	api.mtx.Lock()
+	defer api.mtx.Unlock()
+	api.lookups++
+	tx, ok := api.pool[hash]
+	if !ok || api.lookups <= api.unknownLookups {
+		return nil, nil
+	}
+	return toMap(tx)
+}
+
+func (api *txPoolAPI) GetTransactionReceipt(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	api.mtx.Lock()
+	defer api.mtx.Unlock()
+	if api.mined == nil {
+		for _, tx := range api.pool {
+			if tx.GasPrice().Cmp(api.minGasPrice) >= 0 {
+				api.mined = tx
+			}
+		}
+	}
+	if api.mined == nil || api.mined.Hash() != hash {
+		return nil, nil
+	}
+	return map[string]interface{}{
+		"status":            "0x1",
+		"cumulativeGasUsed": "0x5208",
+		"logsBloom":         gethtypes.Bloom{},
+		"logs":              []interface{}{},
+		"transactionHash":   hash,
+		"gasUsed":           "0x5208",
+		"blockHash":         common.Hash{1},
+		"blockNumber":       "0xa",
+		"transactionIndex":  "0x0",
+	}, nil
+}
+
+func TestTxReplacement(t *testing.T) {
+	ctx := context.Background()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	signer := gethtypes.LatestSignerForChainID(big.NewInt(1))
+	signerFn := func(address common.Address, tx *gethtypes.Transaction) (*gethtypes.Transaction, error) {
+		require.Equal(t, from, address)
+		return gethtypes.SignTx(tx, signer, key)
+	}
+	to := common.Address{2}
+	tx, err := gethtypes.SignNewTx(key, signer, &gethtypes.LegacyTx{Nonce: 3, To: &to, Gas: 100000, GasPrice: big.NewInt(100)})
+	require.NoError(t, err)
+
+	policy := ReplacementPolicy{PollInterval: time.Millisecond, BumpPercent: 10, MaxReplacements: 3}
+
+	// the second replacement is mined
+	api := &txPoolAPI{minGasPrice: big.NewInt(121), pool: map[common.Hash]*gethtypes.Transaction{tx.Hash(): tx}}
+	rc, hashes, err := newTestClient(t, api, WithTxReplacement(policy)).WaitForReceiptAndReplace(ctx, tx, signerFn)
+	require.NoError(t, err)
+	require.Len(t, hashes, 3)
+	require.Equal(t, tx.Hash(), hashes[0])
+	require.Equal(t, hashes[2], rc.TxHash)
+	require.Equal(t, big.NewInt(121), api.mined.GasPrice())
+	require.Equal(t, tx.Nonce(), api.mined.Nonce())
+
+	// the fees are not raised beyond the cap
+	api = &txPoolAPI{minGasPrice: big.NewInt(121), pool: map[common.Hash]*gethtypes.Transaction{tx.Hash(): tx}}
+	policy.MaxFee = big.NewInt(115)
+	_, hashes, err = newTestClient(t, api, WithTxReplacement(policy)).WaitForReceiptAndReplace(ctx, tx, signerFn)
+	require.Error(t, err)
+	// the third replacement is not sent because its fee would be the same as the capped one
+	require.Len(t, hashes, 3)
+	require.Len(t, api.pool, 3)
+	require.Equal(t, big.NewInt(110), api.pool[hashes[1]].GasPrice())
+	require.Equal(t, big.NewInt(115), api.pool[hashes[2]].GasPrice())
+
+	// the transaction that the node does not know in consecutive polls is dropped
+	retryOpt := WithRetryOption(retry.Delay(time.Millisecond), retry.DelayType(retry.FixedDelay))
+	api = &txPoolAPI{minGasPrice: big.NewInt(0), pool: map[common.Hash]*gethtypes.Transaction{}}
+	_, err = newTestClient(t, api, retryOpt).WaitForReceiptAndGet(ctx, tx)
+	require.True(t, errors.Is(err, ErrTxDropped), err)
+	require.Equal(t, DefaultDropConfirmations, api.lookups)
+	policy.MaxReplacements = 0
+	policy.Interval = time.Hour
+	_, _, err = newTestClient(t, api, WithTxReplacement(policy)).WaitForReceiptAndReplace(ctx, tx, signerFn)
+	require.True(t, errors.Is(err, ErrTxDropped), err)
+
+	// the transaction that the node does not know just after it is sent is not dropped
+	api = &txPoolAPI{minGasPrice: big.NewInt(1000), pool: map[common.Hash]*gethtypes.Transaction{tx.Hash(): tx}, unknownLookups: DefaultDropConfirmations - 1}
+	_, err = newTestClient(t, api, retryOpt).WaitForReceiptAndGet(ctx, tx)
+	require.Error(t, err)
+	require.False(t, errors.Is(err, ErrTxDropped), err)
+}
@@ -0,0 +1,281 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"0fatih/yui-ibc-solidity/pkg/contract/erc20"
+	"0fatih/yui-ibc-solidity/pkg/contract/ibccommitmenttesthelper"
+	"0fatih/yui-ibc-solidity/pkg/contract/ibchandler"
+	"0fatih/yui-ibc-solidity/pkg/contract/ics20bank"
+	"0fatih/yui-ibc-solidity/pkg/contract/ics20transferbank"
+)
+
+// the names of the builtin errors of Solidity
+const (
+	RevertErrorName = "Error"
+	RevertPanicName = "Panic"
+)
+
+var (
+	errorSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+	panicSelector = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+)
+
+// panicReasons are the descriptions of the panic codes defined in the Solidity docs.
+var panicReasons = map[uint64]string{
+	0x00: "generic panic",
+	0x01: "assert(false)",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "enum overflow",
+	0x22: "invalid encoded storage byte array accessed",
+	0x31: "out-of-bounds array access; popping on an empty array",
+	0x32: "out-of-bounds access of an array or bytesN",
+	0x41: "out of memory",
+	0x51: "uninitialized function",
+}
+
+// RevertError is the error that a transaction or a call reverted with. Callers can match it with errors.As
+// and inspect Name to distinguish `Error(string)`, `Panic(uint256)` and the custom errors.
+type RevertError struct {
+	// Data is the raw revert data
+	Data []byte
+	// Name is RevertErrorName, RevertPanicName or the name of a custom error. It is empty if the data cannot be decoded.
+	Name string
+	// Signature is the signature of the error, e.g. "Error(string)"
+	Signature string
+	// Reason is the message of `Error(string)`
+	Reason string
+	// PanicCode is the code of `Panic(uint256)`
+	PanicCode *big.Int
+	// Args are the decoded arguments of the error
+	Args []interface{}
+}
+
+func (e *RevertError) Error() string {
+	switch {
+	case e.Name == RevertErrorName:
+		return fmt.Sprintf("revert: %v", e.Reason)
+	case e.Name == RevertPanicName:
+		return fmt.Sprintf("revert: panic: %v (0x%x)", e.PanicReason(), e.PanicCode)
+	case e.Name != "":
+		args := make([]string, len(e.Args))
+		for i, arg := range e.Args {
+			args[i] = fmt.Sprint(arg)
+		}
+		return fmt.Sprintf("revert: %v(%v)", e.Name, strings.Join(args, ", "))
+	case len(e.Data) == 0:
+		return "revert: no data"
+	default:
+		return fmt.Sprintf("revert: unknown error: %v", hexutil.Encode(e.Data))
+	}
+}
+
+// IsPanic returns true if the error is `Panic(uint256)`.
+func (e *RevertError) IsPanic() bool {
+	return e.Name == RevertPanicName
+}
+
+// IsCustom returns true if the error is a custom error defined in an ABI.
+func (e *RevertError) IsCustom() bool {
+	return e.Name != "" && e.Name != RevertErrorName && e.Name != RevertPanicName
+}
+
+// PanicReason returns the description of the panic code.
+func (e *RevertError) PanicReason() string {
+	if e.PanicCode == nil {
+		return ""
+	}
+	if e.PanicCode.IsUint64() {
+		if reason, ok := panicReasons[e.PanicCode.Uint64()]; ok {
+			return reason
+		}
+	}
+	return "unknown panic code"
+}
+
+// RevertDecoder decodes the revert data with the builtin errors and the custom errors of ABIs.
+type RevertDecoder struct {
+	errors map[[4]byte]abi.Error
+}
+
+// NewRevertDecoder returns a decoder that knows the custom errors of the ABIs.
+func NewRevertDecoder(abis ...*abi.ABI) *RevertDecoder {
+	d := &RevertDecoder{errors: make(map[[4]byte]abi.Error)}
+	for _, a := range abis {
+		for _, e := range a.Errors {
+			var selector [4]byte
+			copy(selector[:], e.ID[:4])
+			d.errors[selector] = e
+		}
+	}
+	return d
+}
+
+// Decode decodes the revert data. The data is kept in the returned error even if it cannot be decoded.
+func (d *RevertDecoder) Decode(data []byte) *RevertError {
+	e := &RevertError{Data: data}
+	if len(data) < 4 {
+		return e
+	}
+	switch {
+	case bytes.Equal(data[:4], errorSelector):
+		reason, err := abi.UnpackRevert(data)
+		if err != nil {
+			return e
+		}
+		e.Name, e.Signature, e.Reason, e.Args = RevertErrorName, "Error(string)", reason, []interface{}{reason}
+	case bytes.Equal(data[:4], panicSelector):
+		if len(data) != 4+32 {
+			return e
+		}
+		code := new(big.Int).SetBytes(data[4:])
+		e.Name, e.Signature, e.PanicCode, e.Args = RevertPanicName, "Panic(uint256)", code, []interface{}{code}
+	default:
+		var selector [4]byte
+		copy(selector[:], data[:4])
+		abiErr, ok := d.errors[selector]
+		if !ok {
+			return e
+		}
+		args, err := abiErr.Inputs.Unpack(data[4:])
+		if err != nil {
+			return e
+		}
+		e.Name, e.Signature, e.Args = abiErr.Name, abiErr.Sig, args
+	}
+	return e
+}
+
+var (
+	defaultRevertDecoder     *RevertDecoder
+	defaultRevertDecoderOnce sync.Once
+)
+
+// DecodeRevert decodes the revert data with the ABIs of the bundled contract bindings.
+func DecodeRevert(data []byte) *RevertError {
+	defaultRevertDecoderOnce.Do(func() {
+		var abis []*abi.ABI
+		for _, md := range []*bind.MetaData{
+			erc20.Erc20MetaData,
+			ibccommitmenttesthelper.IbccommitmenttesthelperMetaData,
+			ibchandler.IbchandlerMetaData,
+			ics20bank.Ics20bankMetaData,
+			ics20transferbank.Ics20transferbankMetaData,
+		} {
+			parsed, err := md.GetAbi()
+			if err != nil {
+				panic(err)
+			}
+			abis = append(abis, parsed)
+		}
+		defaultRevertDecoder = NewRevertDecoder(abis...)
+	})
+	return defaultRevertDecoder.Decode(data)
+}
+
+// RevertRecovery is how the revert data of a failed transaction is recovered if the receipt does not have
+// the non-standard revertReason field, which only Besu returns.
+type RevertRecovery int
+
+const (
+	// RevertRecoveryNone does not recover the revert data
+	RevertRecoveryNone RevertRecovery = iota
+	// RevertRecoveryCall replays the transaction with eth_call on the state of the parent block
+	RevertRecoveryCall
+	// RevertRecoveryTrace gets the revert data with debug_traceTransaction. It falls back to RevertRecoveryCall
+	// if the node does not serve the debug namespace.
+	RevertRecoveryTrace
+)
+
+// RecoverRevertData returns the revert data of the failed transaction of the receipt with the recovery of the client.
+// An empty data is returned if the transaction failed without revert data, e.g. because it ran out of gas.
+func (cl *ETHClient) RecoverRevertData(ctx context.Context, receipt *gethtypes.Receipt) ([]byte, error) {
+	switch cl.option.revertRecovery {
+	case RevertRecoveryNone:
+		return nil, nil
+	case RevertRecoveryTrace:
+		if data, err := cl.traceRevertData(ctx, receipt.TxHash); err == nil {
+			return data, nil
+		}
+		return cl.replayRevertData(ctx, receipt)
+	default:
+		return cl.replayRevertData(ctx, receipt)
+	}
+}
+
+// replayRevertData replays the transaction with eth_call. The result may differ from the actual execution
+// if the preceding transactions in the same block changed the state that the transaction depends on.
+func (cl *ETHClient) replayRevertData(ctx context.Context, receipt *gethtypes.Receipt) ([]byte, error) {
+	tx, _, err := cl.TransactionByHash(ctx, receipt.TxHash)
+	if err != nil {
+		return nil, err
+	}
+	from, err := gethtypes.Sender(gethtypes.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil, err
+	}
+	var parent *big.Int
+	if receipt.BlockNumber != nil && receipt.BlockNumber.Sign() > 0 {
+		parent = new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1))
+	}
+	_, err = cl.CallContract(ctx, ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}, parent)
+	if err == nil {
+		return nil, errors.New("the replayed transaction succeeded")
+	}
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil, err
+	}
+	return errorDataToBytes(dataErr.ErrorData())
+}
+
+// traceRevertData gets the output of the transaction with the callTracer of debug_traceTransaction.
+func (cl *ETHClient) traceRevertData(ctx context.Context, txHash common.Hash) ([]byte, error) {
+	var result struct {
+		Output hexutil.Bytes `json:"output"`
+		Error  string        `json:"error"`
+	}
+	if err := cl.rpcClient.CallContext(ctx, &result, "debug_traceTransaction", txHash, map[string]interface{}{"tracer": "callTracer"}); err != nil {
+		return nil, err
+	}
+	if result.Error == "" {
+		return nil, fmt.Errorf("the traced transaction succeeded: %v", txHash)
+	}
+	return result.Output, nil
+}
+
+// errorDataToBytes converts the data of a JSON-RPC error, which is a hex string in go-ethereum, to bytes.
+func errorDataToBytes(data interface{}) ([]byte, error) {
+	switch v := data.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return hexutil.Decode(v)
+	case []byte:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("unexpected error data: %T", data)
+	}
+}
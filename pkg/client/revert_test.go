@@ -0,0 +1,53 @@
+package client
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/require"
+)
+
+const testErrorsABI = `[{"type":"error","name":"InsufficientBalance","inputs":[{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]}]`
+
+func TestDecodeRevert(t *testing.T) {
+	// Error(string)
+	e := DecodeRevert(hexToBytes("0x08c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000001a4e6f7420656e6f7567682045746865722070726f76696465642e000000000000"))
+	require.Equal(t, RevertErrorName, e.Name)
+	require.Equal(t, "Not enough Ether provided.", e.Reason)
+	require.Equal(t, "revert: Not enough Ether provided.", e.Error())
+
+	// Panic(uint256) of an arithmetic overflow
+	e = DecodeRevert(hexToBytes("0x4e487b710000000000000000000000000000000000000000000000000000000000000011"))
+	require.True(t, e.IsPanic())
+	require.Equal(t, big.NewInt(0x11), e.PanicCode)
+	require.Equal(t, "revert: panic: arithmetic underflow or overflow (0x11)", e.Error())
+	reason, err := parseRevertReason(e.Data)
+	require.NoError(t, err)
+	require.Equal(t, "panic: arithmetic underflow or overflow (0x11)", reason)
+
+	// unknown custom error
+	e = DecodeRevert(hexToBytes("0xcf47918100000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002"))
+	require.Empty(t, e.Name)
+	require.True(t, strings.HasPrefix(e.Error(), "revert: unknown error: 0xcf479181"))
+	_, err = parseRevertReason(e.Data)
+	require.Error(t, err)
+
+	// custom error of an ABI
+	parsed, err := abi.JSON(strings.NewReader(testErrorsABI))
+	require.NoError(t, err)
+	abiErr := parsed.Errors["InsufficientBalance"]
+	data, err := abiErr.Inputs.Pack(big.NewInt(1), big.NewInt(2))
+	require.NoError(t, err)
+	e = NewRevertDecoder(&parsed).Decode(append(abiErr.ID[:4:4], data...))
+	require.True(t, e.IsCustom())
+	require.Equal(t, "InsufficientBalance(uint256,uint256)", e.Signature)
+	require.Equal(t, []interface{}{big.NewInt(1), big.NewInt(2)}, e.Args)
+	require.Equal(t, "revert: InsufficientBalance(1, 2)", e.Error())
+
+	// callers can match the structured error
+	var revertErr *RevertError
+	require.True(t, errors.As(error(e), &revertErr))
+}
@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// the size of the buffer between the subscription and the sink
+const watchLogsBufferSize = 128
+
+// errUnsubscribed is returned internally when the subscription is unsubscribed by the caller
+var errUnsubscribed = errors.New("unsubscribed")
+
+// logBackend is the subset of ETHClient that WatchLogs uses.
+type logBackend interface {
+	ethereum.LogFilterer
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// WatchLogs subscribes to the logs that match the query and delivers them to the sink in order.
+// The endpoint must support subscriptions, i.e. it must be a websocket or an IPC endpoint.
+//
+// If the subscription fails, e.g. because of a disconnection, WatchLogs resubscribes with exponential backoff
+// (see WithResubscribeBackoff) and fetches the logs emitted in the meantime with eth_getLogs, so that no log is
+// missed or delivered twice. If `query.FromBlock` is set, the logs from the block are delivered first.
+// The returned subscription fails only if the context is done.
+func (cl *ETHClient) WatchLogs(ctx context.Context, query ethereum.FilterQuery, sink chan<- gethtypes.Log) (event.Subscription, error) {
+	return watchLogs(ctx, cl, query, sink, cl.option.resubscribeBackoff)
+}
+
+func watchLogs(ctx context.Context, backend logBackend, query ethereum.FilterQuery, sink chan<- gethtypes.Log, backoffMax time.Duration) (event.Subscription, error) {
+	w := &logWatcher{backend: backend, query: query}
+	if query.FromBlock != nil {
+		w.cursor.next = query.FromBlock.Uint64()
+		w.backfill = true
+	}
+	logs := make(chan gethtypes.Log, watchLogsBufferSize)
+	// the first subscription is made synchronously to return an error if the endpoint does not support subscriptions
+	sub, pending, err := w.subscribe(ctx, logs)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		err := w.run(ctx, quit, sub, pending, logs, sink, backoffMax)
+		if errors.Is(err, errUnsubscribed) {
+			return nil
+		}
+		return err
+	}), nil
+}
+
+type logWatcher struct {
+	backend logBackend
+	query   ethereum.FilterQuery
+	cursor  logCursor
+	// backfill is true if the logs from `cursor.next` must be fetched after subscribing
+	backfill bool
+}
+
+func (w *logWatcher) run(ctx context.Context, quit <-chan struct{}, sub event.Subscription, pending []gethtypes.Log, logs chan gethtypes.Log, sink chan<- gethtypes.Log, backoffMax time.Duration) error {
+	defer func() {
+		if sub != nil {
+			sub.Unsubscribe()
+		}
+	}()
+	for {
+		for _, log := range pending {
+			if err := w.deliver(ctx, quit, sink, log); err != nil {
+				return err
+			}
+		}
+		pending = nil
+		select {
+		case log := <-logs:
+			if err := w.deliver(ctx, quit, sink, log); err != nil {
+				return err
+			}
+		case <-sub.Err():
+			sub.Unsubscribe()
+			sub = nil
+			// the logs buffered in the old channel are fetched again by the backfill
+			logs = make(chan gethtypes.Log, watchLogsBufferSize)
+			var err error
+			if sub, pending, err = w.resubscribe(ctx, quit, logs, backoffMax); err != nil {
+				return err
+			}
+		case <-quit:
+			return errUnsubscribed
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// subscribe subscribes to the new logs and returns the logs emitted since the cursor.
+func (w *logWatcher) subscribe(ctx context.Context, logs chan<- gethtypes.Log) (event.Subscription, []gethtypes.Log, error) {
+	query := w.query
+	query.FromBlock, query.ToBlock = nil, nil
+	sub, err := w.backend.SubscribeFilterLogs(ctx, query, logs)
+	if err != nil {
+		return nil, nil, err
+	}
+	latest, err := w.backend.BlockNumber(ctx)
+	if err != nil {
+		sub.Unsubscribe()
+		return nil, nil, err
+	}
+	if !w.backfill {
+		// the logs after the latest block are delivered by the subscription, so the first backfill starts from the next block
+		w.cursor.next = latest + 1
+		w.backfill = true
+		return sub, nil, nil
+	}
+	if w.cursor.next > latest {
+		return sub, nil, nil
+	}
+	query.FromBlock = new(big.Int).SetUint64(w.cursor.next)
+	query.ToBlock = new(big.Int).SetUint64(latest)
+	pending, err := w.backend.FilterLogs(ctx, query)
+	if err != nil {
+		sub.Unsubscribe()
+		return nil, nil, err
+	}
+	return sub, pending, nil
+}
+
+func (w *logWatcher) resubscribe(ctx context.Context, quit <-chan struct{}, logs chan<- gethtypes.Log, backoffMax time.Duration) (event.Subscription, []gethtypes.Log, error) {
+	backoff := 10 * time.Millisecond
+	for {
+		sub, pending, err := w.subscribe(ctx, logs)
+		if err == nil {
+			return sub, pending, nil
+		}
+		select {
+		case <-time.After(backoff):
+		case <-quit:
+			return nil, nil, errUnsubscribed
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+		if backoff *= 2; backoff > backoffMax {
+			backoff = backoffMax
+		}
+	}
+}
+
+func (w *logWatcher) deliver(ctx context.Context, quit <-chan struct{}, sink chan<- gethtypes.Log, log gethtypes.Log) error {
+	if !w.cursor.accept(log) {
+		return nil
+	}
+	select {
+	case sink <- log:
+		return nil
+	case <-quit:
+		return errUnsubscribed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// logCursor tracks the position of the last delivered log to skip the logs that are delivered twice
+// by a backfill and a subscription.
+type logCursor struct {
+	// next is the first block whose logs may not have been delivered
+	next uint64
+
+	delivered bool
+	block     uint64
+	index     uint
+}
+
+func (c *logCursor) accept(log gethtypes.Log) bool {
+	// the removed logs of a reorg are always delivered and the logs of the new chain are accepted again
+	if log.Removed {
+		if c.delivered && (log.BlockNumber < c.block || log.BlockNumber == c.block && log.Index <= c.index) {
+			if log.BlockNumber == 0 {
+				c.delivered = false
+			} else {
+				c.block, c.index = log.BlockNumber-1, ^uint(0)
+			}
+		}
+		return true
+	}
+	if c.delivered && (log.BlockNumber < c.block || log.BlockNumber == c.block && log.Index <= c.index) {
+		return false
+	}
+	c.delivered = true
+	c.block, c.index = log.BlockNumber, log.Index
+	// the rest of the logs in the block may not have been delivered yet
+	c.next = log.BlockNumber
+	return true
+}
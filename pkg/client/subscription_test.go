@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLogBackend emits the logs of `blocks` and fails the current subscription on `disconnect`.
+type fakeLogBackend struct {
+	mu     sync.Mutex
+	blocks [][]gethtypes.Log
+	subs   []chan<- gethtypes.Log
+	fail   chan error
+}
+
+func (b *fakeLogBackend) mine(n int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	number := uint64(len(b.blocks))
+	var logs []gethtypes.Log
+	for i := 0; i < n; i++ {
+		logs = append(logs, gethtypes.Log{BlockNumber: number, Index: uint(i)})
+	}
+	b.blocks = append(b.blocks, logs)
+	for _, sub := range b.subs {
+		for _, log := range logs {
+			sub <- log
+		}
+	}
+}
+
+func (b *fakeLogBackend) disconnect() {
+	b.mu.Lock()
+	b.subs = nil
+	fail := b.fail
+	b.mu.Unlock()
+	fail <- errors.New("disconnected")
+}
+
+func (b *fakeLogBackend) BlockNumber(ctx context.Context) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return uint64(len(b.blocks)) - 1, nil
+}
+
+func (b *fakeLogBackend) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]gethtypes.Log, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var logs []gethtypes.Log
+	for _, block := range b.blocks[q.FromBlock.Uint64() : q.ToBlock.Uint64()+1] {
+		logs = append(logs, block...)
+	}
+	return logs, nil
+}
+
+func (b *fakeLogBackend) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- gethtypes.Log) (ethereum.Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subs = append(b.subs, ch)
+	fail := make(chan error, 1)
+	b.fail = fail
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		select {
+		case err := <-fail:
+			return err
+		case <-quit:
+			return nil
+		}
+	}), nil
+}
+
+func TestWatchLogs(t *testing.T) {
+	ctx := context.Background()
+	backend := &fakeLogBackend{}
+	backend.mine(2)
+	backend.mine(1)
+
+	sink := make(chan gethtypes.Log)
+	sub, err := watchLogs(ctx, backend, ethereum.FilterQuery{}, sink, time.Millisecond)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	// the logs before the subscription are not delivered without FromBlock
+	go backend.mine(2)
+	requireLogs(t, sink, [][2]uint64{{2, 0}, {2, 1}})
+
+	// the logs emitted while disconnected are backfilled
+	backend.disconnect()
+	backend.mine(1)
+	requireLogs(t, sink, [][2]uint64{{3, 0}})
+	go backend.mine(1)
+	requireLogs(t, sink, [][2]uint64{{4, 0}})
+}
+
+func TestWatchLogsFromBlock(t *testing.T) {
+	ctx := context.Background()
+	backend := &fakeLogBackend{}
+	backend.mine(2)
+	backend.mine(1)
+
+	sink := make(chan gethtypes.Log)
+	sub, err := watchLogs(ctx, backend, ethereum.FilterQuery{FromBlock: big.NewInt(0)}, sink, time.Millisecond)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+	requireLogs(t, sink, [][2]uint64{{0, 0}, {0, 1}, {1, 0}})
+}
+
+func TestLogCursor(t *testing.T) {
+	var c logCursor
+	require.True(t, c.accept(gethtypes.Log{BlockNumber: 1, Index: 0}))
+	require.True(t, c.accept(gethtypes.Log{BlockNumber: 1, Index: 1}))
+	// duplicates are skipped
+	require.False(t, c.accept(gethtypes.Log{BlockNumber: 1, Index: 1}))
+	require.False(t, c.accept(gethtypes.Log{BlockNumber: 0, Index: 5}))
+	require.Equal(t, uint64(1), c.next)
+	require.True(t, c.accept(gethtypes.Log{BlockNumber: 2, Index: 0}))
+
+	// the logs of a new chain are accepted after a reorg
+	require.True(t, c.accept(gethtypes.Log{BlockNumber: 2, Index: 0, Removed: true}))
+	require.True(t, c.accept(gethtypes.Log{BlockNumber: 2, Index: 0}))
+}
+
+func requireLogs(t *testing.T, sink <-chan gethtypes.Log, expected [][2]uint64) {
+	for _, pos := range expected {
+		select {
+		case log := <-sink:
+			require.Equal(t, pos, [2]uint64{log.BlockNumber, uint64(log.Index)})
+		case <-time.After(5 * time.Second):
+			t.Fatalf("log is not delivered: %v", pos)
+		}
+	}
+	select {
+	case log := <-sink:
+		t.Fatalf("unexpected log: %v", log)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
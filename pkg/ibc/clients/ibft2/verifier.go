@@ -0,0 +1,315 @@
+package ibft2
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"0fatih/yui-ibc-solidity/pkg/client"
+	ibcclient "0fatih/yui-ibc-solidity/pkg/ibc/core/client"
+	"0fatih/yui-ibc-solidity/pkg/ibc/core/commitment"
+)
+
+// the index of the storage root in an account
+const accountStorageRootIndex = 2
+
+// RevisionNumber is the revision number of the heights of a Besu chain. IBFT2Client.sol builds the height of a header
+// from its block number only, so a client cannot track a chain whose IBC chain ID has another revision number.
+const RevisionNumber uint64 = 0
+
+// CommitmentSlot is the slot of the commitments mapping that IBFT2Client.sol verifies the proofs at. It is fixed by
+// COMMITMENT_SLOT of the contract, so a client cannot track an IBCHandler whose commitments mapping is at another slot.
+var CommitmentSlot = commitment.DefaultCommitmentSlot
+
+var (
+	// ErrUnsupportedRevisionNumber is returned if a height has a revision number other than RevisionNumber.
+	ErrUnsupportedRevisionNumber = errors.New("unsupported revision number")
+	// ErrUnsupportedCommitmentSlot is returned if a commitment layout has a commitment slot other than CommitmentSlot.
+	ErrUnsupportedCommitmentSlot = errors.New("unsupported commitment slot")
+)
+
+// Verifier is an off-chain light client that verifies IBFT2 headers and state proofs in the same way as
+// IBFT2Client.sol. Please see docs/ibft2-light-client.md for the client spec.
+//
+// Unlike the contract, the verifier also checks the trusting period of the trusted consensus state.
+// The delay periods of the proofs are not checked because they depend on the host chain.
+type Verifier struct {
+	ClientState     ClientState
+	ConsensusStates map[ibcclient.Height]*ConsensusState
+	// TrustingPeriod is the period in which a consensus state can be trusted. Zero means infinite.
+	TrustingPeriod time.Duration
+}
+
+// ParsedBesuHeader is a Besu header whose extra data does not contain the commit seals.
+type ParsedBesuHeader struct {
+	Base       *gethtypes.Header
+	Height     ibcclient.Height
+	Validators []common.Address
+}
+
+// NewVerifier returns a Verifier initialized with the trusted states.
+func NewVerifier(clientState ClientState, consensusState ConsensusState, trustingPeriod time.Duration) *Verifier {
+	return &Verifier{
+		ClientState: clientState,
+		ConsensusStates: map[ibcclient.Height]*ConsensusState{
+			clientState.LatestHeight: &consensusState,
+		},
+		TrustingPeriod: trustingPeriod,
+	}
+}
+
+// ValidateCommitmentLayout returns an error if the commitments mapping of the layout is not at CommitmentSlot.
+func ValidateCommitmentLayout(layout commitment.Layout) error {
+	if layout.CommitmentSlot != CommitmentSlot {
+		return fmt.Errorf("%w: expected=%v actual=%v", ErrUnsupportedCommitmentSlot, CommitmentSlot, layout.CommitmentSlot)
+	}
+	return nil
+}
+
+// GetConsensusState returns the consensus state at the height.
+func (v *Verifier) GetConsensusState(height ibcclient.Height) (*ConsensusState, bool) {
+	cs, ok := v.ConsensusStates[height]
+	return cs, ok
+}
+
+// VerifyHeader verifies the header against the trusted consensus state at `header.TrustedHeight` and
+// returns the height and the consensus state of the header. The states of the verifier are not updated.
+func (v *Verifier) VerifyHeader(header *Header, now time.Time) (ibcclient.Height, *ConsensusState, error) {
+	if header.TrustedHeight.RevisionNumber != RevisionNumber {
+		return ibcclient.Height{}, nil, fmt.Errorf("%w: trusted_height=%v", ErrUnsupportedRevisionNumber, header.TrustedHeight)
+	}
+	parsed, err := ParseBesuHeader(header.BesuHeaderRlp)
+	if err != nil {
+		return ibcclient.Height{}, nil, err
+	}
+	if !parsed.Height.GT(header.TrustedHeight) {
+		return ibcclient.Height{}, nil, fmt.Errorf("header height <= consensus state height: %v <= %v", parsed.Height, header.TrustedHeight)
+	}
+	trusted, ok := v.ConsensusStates[header.TrustedHeight]
+	if !ok {
+		return ibcclient.Height{}, nil, fmt.Errorf("consensus state not found: height=%v", header.TrustedHeight)
+	}
+	if err := v.checkTrustingPeriod(trusted, now); err != nil {
+		return ibcclient.Height{}, nil, err
+	}
+
+	blkHash := crypto.Keccak256(header.BesuHeaderRlp)
+	if err := verifyCommitSealsTrusting(trusted.Validators, header.Seals, blkHash, 1, 3); err != nil {
+		return ibcclient.Height{}, nil, err
+	}
+	if err := verifyCommitSeals(parsed.Validators, header.Seals, blkHash); err != nil {
+		return ibcclient.Height{}, nil, err
+	}
+
+	storageRoot, err := VerifyAccountStorageRoot(common.BytesToAddress(v.ClientState.IbcStoreAddress), parsed.Base.Root, header.AccountStateProof)
+	if err != nil {
+		return ibcclient.Height{}, nil, err
+	}
+	validators := make([][]byte, len(parsed.Validators))
+	for i, val := range parsed.Validators {
+		validators[i] = val.Bytes()
+	}
+	return parsed.Height, &ConsensusState{
+		Timestamp:  parsed.Base.Time,
+		Root:       storageRoot.Bytes(),
+		Validators: validators,
+	}, nil
+}
+
+// UpdateClient verifies the header and stores the consensus state of the header.
+func (v *Verifier) UpdateClient(header *Header, now time.Time) (ibcclient.Height, error) {
+	height, cs, err := v.VerifyHeader(header, now)
+	if err != nil {
+		return ibcclient.Height{}, err
+	}
+	if height.GT(v.ClientState.LatestHeight) {
+		v.ClientState.LatestHeight = height
+	}
+	v.ConsensusStates[height] = cs
+	return height, nil
+}
+
+// VerifyMembership verifies that `value` is committed at `path` in the consensus state at the height.
+func (v *Verifier) VerifyMembership(height ibcclient.Height, proof []byte, prefix []byte, path []byte, value []byte) error {
+	cs, err := v.validateArgs(height, prefix, proof)
+	if err != nil {
+		return err
+	}
+	committed, err := VerifyStorageProof(common.BytesToHash(cs.Root), common.HexToHash(commitment.CalculateCommitmentSlot(path)), proof)
+	if err != nil {
+		return err
+	}
+	if expected := crypto.Keccak256Hash(value); committed != expected {
+		return fmt.Errorf("commitment mismatch: expected=%v actual=%v", expected, committed)
+	}
+	return nil
+}
+
+// VerifyNonMembership verifies that nothing is committed at `path` in the consensus state at the height.
+func (v *Verifier) VerifyNonMembership(height ibcclient.Height, proof []byte, prefix []byte, path []byte) error {
+	cs, err := v.validateArgs(height, prefix, proof)
+	if err != nil {
+		return err
+	}
+	committed, err := VerifyStorageProof(common.BytesToHash(cs.Root), common.HexToHash(commitment.CalculateCommitmentSlot(path)), proof)
+	if err != nil {
+		return err
+	}
+	if committed != (common.Hash{}) {
+		return fmt.Errorf("commitment exists: path=%s commitment=%v", path, committed)
+	}
+	return nil
+}
+
+func (v *Verifier) validateArgs(height ibcclient.Height, prefix []byte, proof []byte) (*ConsensusState, error) {
+	if v.ClientState.LatestHeight.LT(height) {
+		return nil, fmt.Errorf("height is greater than the latest height: %v > %v", height, v.ClientState.LatestHeight)
+	} else if len(prefix) == 0 {
+		return nil, errors.New("prefix is empty")
+	} else if len(proof) == 0 {
+		return nil, errors.New("proof is empty")
+	}
+	cs, ok := v.ConsensusStates[height]
+	if !ok {
+		return nil, fmt.Errorf("consensus state not found: height=%v", height)
+	}
+	return cs, nil
+}
+
+func (v *Verifier) checkTrustingPeriod(cs *ConsensusState, now time.Time) error {
+	trustedTime := time.Unix(int64(cs.Timestamp), 0)
+	// IBFT2Client.sol accepts a consensus state whose timestamp is equal to the current time
+	if now.Before(trustedTime) {
+		return fmt.Errorf("trusted consensus state is in the future: timestamp=%v now=%v", trustedTime, now)
+	}
+	if v.TrustingPeriod != 0 && !now.Before(trustedTime.Add(v.TrustingPeriod)) {
+		return fmt.Errorf("trusted consensus state is expired: timestamp=%v trusting_period=%v now=%v", trustedTime, v.TrustingPeriod, now)
+	}
+	return nil
+}
+
+// ParseBesuHeader parses the RLP of a Besu header for sealing, whose extra data is [vanity, validators, vote, round].
+func ParseBesuHeader(besuHeaderRLP []byte) (*ParsedBesuHeader, error) {
+	var items []rlp.RawValue
+	if err := rlp.DecodeBytes(besuHeaderRLP, &items); err != nil {
+		return nil, err
+	} else if len(items) != 15 {
+		return nil, fmt.Errorf("items length must be 15: actual=%v", len(items))
+	}
+	var header gethtypes.Header
+	if err := rlp.DecodeBytes(besuHeaderRLP, &header); err != nil {
+		return nil, err
+	}
+	var extra []rlp.RawValue
+	if err := rlp.DecodeBytes(header.Extra, &extra); err != nil {
+		return nil, err
+	} else if len(extra) != 4 {
+		return nil, fmt.Errorf("extra length must be 4: actual=%v", len(extra))
+	}
+	var validators []common.Address
+	if err := rlp.DecodeBytes(extra[1], &validators); err != nil {
+		return nil, err
+	}
+	return &ParsedBesuHeader{
+		Base:       &header,
+		Height:     ibcclient.NewHeightFromBN(header.Number),
+		Validators: validators,
+	}, nil
+}
+
+// verifyCommitSealsTrusting verifies that at least numerator/denominator of the trusted validators signed the block.
+func verifyCommitSealsTrusting(trustedVals [][]byte, seals [][]byte, blkHash []byte, numerator, denominator int) error {
+	success := 0
+	marked := make([]bool, len(trustedVals))
+	for _, seal := range seals {
+		if len(seal) == 0 {
+			continue
+		}
+		signer, err := ecdsaRecover(blkHash, seal)
+		if err != nil {
+			return err
+		}
+		for j, val := range trustedVals {
+			if !marked[j] && common.BytesToAddress(val) == signer {
+				success++
+				marked[j] = true
+			}
+		}
+	}
+	if threshold := len(trustedVals) * numerator / denominator; success < threshold {
+		return fmt.Errorf("insufficient trusted voting: %v < %v", success, threshold)
+	}
+	return nil
+}
+
+// verifyCommitSeals verifies that more than 2/3 of the validators signed the block. The order of the seals
+// must match the order of the validators.
+func verifyCommitSeals(vals []common.Address, seals [][]byte, blkHash []byte) error {
+	if len(seals) > len(vals) {
+		return fmt.Errorf("too many seals: seals=%v validators=%v", len(seals), len(vals))
+	}
+	success := 0
+	for i, seal := range seals {
+		if len(seal) == 0 {
+			continue
+		}
+		signer, err := ecdsaRecover(blkHash, seal)
+		if err != nil {
+			return err
+		}
+		if signer == vals[i] {
+			success++
+		}
+	}
+	if threshold := len(vals) * 2 / 3; success <= threshold {
+		return fmt.Errorf("insufficient voting: %v <= %v", success, threshold)
+	}
+	return nil
+}
+
+// ecdsaRecover returns the signer of the seal. The recovery id of the seal can be either 0/1 or 27/28.
+func ecdsaRecover(hash []byte, seal []byte) (common.Address, error) {
+	if len(seal) != 65 {
+		return common.Address{}, fmt.Errorf("seal length must be 65: actual=%v", len(seal))
+	}
+	sig := make([]byte, 65)
+	copy(sig, seal)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	pub, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		// same as the contract, an invalid seal is not counted
+		return common.Address{}, nil
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// VerifyAccountStorageRoot verifies the account proof of `account` against the state root and returns the storage root of the account.
+func VerifyAccountStorageRoot(account common.Address, stateRoot common.Hash, accountProof []byte) (common.Hash, error) {
+	accountRLP, err := client.VerifyRLPProof(stateRoot, crypto.Keccak256(account.Bytes()), accountProof)
+	if err != nil {
+		return common.Hash{}, err
+	} else if len(accountRLP) == 0 {
+		return common.Hash{}, fmt.Errorf("account not found: %v", account)
+	}
+	var items [][]byte
+	if err := rlp.DecodeBytes(accountRLP, &items); err != nil {
+		return common.Hash{}, err
+	} else if len(items) <= accountStorageRootIndex {
+		return common.Hash{}, fmt.Errorf("unexpected account: %x", accountRLP)
+	}
+	return common.BytesToHash(items[accountStorageRootIndex]), nil
+}
+
+// VerifyStorageProof verifies the storage proof of the slot against the storage root and returns the value.
+// The zero value is returned if the slot is empty.
+func VerifyStorageProof(storageRoot common.Hash, slot common.Hash, storageProof []byte) (common.Hash, error) {
+	value, _, err := client.VerifyStorageProof(storageRoot, slot, storageProof)
+	return value, err
+}
@@ -0,0 +1,186 @@
+package ibft2
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+
+	"0fatih/yui-ibc-solidity/pkg/ibc/core/client"
+	"0fatih/yui-ibc-solidity/pkg/ibc/core/commitment"
+)
+
+var (
+	testIBCStoreAddress = common.HexToAddress("0xaa43d337145E8930d01cb4E60Abf6595C692921E")
+	testPath            = []byte("commitments/ports/transfer/channels/channel-0/sequences/1")
+	testValue           = []byte("value")
+)
+
+func TestVerifier(t *testing.T) {
+	keys := generateKeys(t, 4)
+	trustedTime := time.Unix(1_000_000, 0)
+	verifier := NewVerifier(
+		ClientState{ChainId: "1", IbcStoreAddress: testIBCStoreAddress.Bytes(), LatestHeight: client.Height{RevisionHeight: 10}},
+		ConsensusState{Timestamp: uint64(trustedTime.Unix()), Validators: validatorBytes(keys)},
+		time.Hour,
+	)
+	now := trustedTime.Add(time.Minute)
+
+	// valid header
+	header, storageProof, absenceProof := makeHeader(t, 11, trustedTime.Add(time.Second), keys, keys)
+	height, err := verifier.UpdateClient(header, now)
+	require.NoError(t, err)
+	require.Equal(t, client.Height{RevisionHeight: 11}, height)
+	require.Equal(t, height, verifier.ClientState.LatestHeight)
+
+	require.NoError(t, verifier.VerifyMembership(height, storageProof, []byte("ibc"), testPath, testValue))
+	require.Error(t, verifier.VerifyMembership(height, storageProof, []byte("ibc"), testPath, []byte("other")))
+	require.Error(t, verifier.VerifyMembership(client.Height{RevisionHeight: 12}, storageProof, []byte("ibc"), testPath, testValue))
+	require.NoError(t, verifier.VerifyNonMembership(height, absenceProof, []byte("ibc"), []byte("other")))
+	require.Error(t, verifier.VerifyNonMembership(height, storageProof, []byte("ibc"), testPath))
+
+	// the heights of a Besu chain have the revision number 0 only
+	header, _, _ = makeHeader(t, 12, trustedTime.Add(time.Second), keys, keys)
+	header.TrustedHeight = client.Height{RevisionNumber: 1, RevisionHeight: 11}
+	_, _, err = verifier.VerifyHeader(header, now)
+	require.ErrorIs(t, err, ErrUnsupportedRevisionNumber)
+
+	// the contract verifies the proofs at the fixed commitment slot only
+	require.NoError(t, ValidateCommitmentLayout(commitment.DefaultLayout))
+	require.ErrorIs(t, ValidateCommitmentLayout(commitment.NewLayout(common.HexToHash("0x01"))), ErrUnsupportedCommitmentSlot)
+
+	// the header height must be greater than the trusted height
+	header, _, _ = makeHeader(t, 10, trustedTime.Add(time.Second), keys, keys)
+	_, _, err = verifier.VerifyHeader(header, now)
+	require.Error(t, err)
+
+	// the trusted consensus state is expired
+	header, _, _ = makeHeader(t, 12, trustedTime.Add(time.Second), keys, keys)
+	header.TrustedHeight = client.Height{RevisionHeight: 10}
+	_, _, err = verifier.VerifyHeader(header, trustedTime.Add(2*time.Hour))
+	require.Error(t, err)
+	_, _, err = verifier.VerifyHeader(header, now)
+	require.NoError(t, err)
+
+	// the current time may be equal to the timestamp of the trusted consensus state, but not before it
+	_, _, err = verifier.VerifyHeader(header, trustedTime)
+	require.NoError(t, err)
+	_, _, err = verifier.VerifyHeader(header, trustedTime.Add(-time.Second))
+	require.Error(t, err)
+
+	// 2/3 of the validators are not enough
+	header, _, _ = makeHeader(t, 12, trustedTime.Add(time.Second), keys, keys[:2])
+	_, _, err = verifier.VerifyHeader(header, now)
+	require.Error(t, err)
+
+	// none of the trusted validators signed the header
+	newKeys := generateKeys(t, 4)
+	header, _, _ = makeHeader(t, 12, trustedTime.Add(time.Second), newKeys, newKeys)
+	_, _, err = verifier.VerifyHeader(header, now)
+	require.Error(t, err)
+
+	// 1/3 of the trusted validators signed the header, which is rounded down as the contract
+	newKeys = append(generateKeys(t, 3), keys[0])
+	header, _, _ = makeHeader(t, 12, trustedTime.Add(time.Second), newKeys, newKeys)
+	_, _, err = verifier.VerifyHeader(header, now)
+	require.NoError(t, err)
+}
+
+// makeHeader returns a header signed by the signers and the storage proofs of testPath and an empty path.
+func makeHeader(t *testing.T, number int64, tm time.Time, validators, signers []*ecdsa.PrivateKey) (*Header, []byte, []byte) {
+	storageTrie := trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase()))
+	slotKey := crypto.Keccak256(common.HexToHash(commitment.CalculateCommitmentSlot(testPath)).Bytes())
+	value, err := rlp.EncodeToBytes(crypto.Keccak256(testValue))
+	require.NoError(t, err)
+	require.NoError(t, storageTrie.Update(slotKey, value))
+	storageProof := prove(t, storageTrie, slotKey)
+	emptySlotKey := crypto.Keccak256(common.HexToHash(commitment.CalculateCommitmentSlot([]byte("other"))).Bytes())
+	absenceProof := prove(t, storageTrie, emptySlotKey)
+
+	stateTrie := trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase()))
+	account, err := rlp.EncodeToBytes(&gethtypes.StateAccount{
+		Balance:  big.NewInt(0),
+		Root:     storageTrie.Hash(),
+		CodeHash: crypto.Keccak256(nil),
+	})
+	require.NoError(t, err)
+	accountKey := crypto.Keccak256(testIBCStoreAddress.Bytes())
+	require.NoError(t, stateTrie.Update(accountKey, account))
+
+	var addrs []common.Address
+	for _, key := range validators {
+		addrs = append(addrs, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	extra, err := rlp.EncodeToBytes([]interface{}{[32]byte{}, addrs, []interface{}{}, [4]byte{}})
+	require.NoError(t, err)
+	besuHeaderRLP, err := rlp.EncodeToBytes(&gethtypes.Header{
+		Number:     big.NewInt(number),
+		Time:       uint64(tm.Unix()),
+		Root:       stateTrie.Hash(),
+		Difficulty: big.NewInt(1),
+		Extra:      extra,
+	})
+	require.NoError(t, err)
+
+	// the seals are ordered by the validators
+	seals := make([][]byte, len(validators))
+	for i, key := range validators {
+		for _, signer := range signers {
+			if key == signer {
+				seals[i], err = crypto.Sign(crypto.Keccak256(besuHeaderRLP), key)
+				require.NoError(t, err)
+			}
+		}
+	}
+	return &Header{
+		BesuHeaderRlp:     besuHeaderRLP,
+		Seals:             seals,
+		TrustedHeight:     client.Height{RevisionHeight: uint64(number) - 1},
+		AccountStateProof: prove(t, stateTrie, accountKey),
+	}, storageProof, absenceProof
+}
+
+type proofList []rlp.RawValue
+
+func (l *proofList) Put(key []byte, value []byte) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func (l *proofList) Delete(key []byte) error {
+	panic("not supported")
+}
+
+func prove(t *testing.T, tr *trie.Trie, key []byte) []byte {
+	var nodes proofList
+	require.NoError(t, tr.Prove(key, 0, &nodes))
+	bz, err := rlp.EncodeToBytes(nodes)
+	require.NoError(t, err)
+	return bz
+}
+
+func generateKeys(t *testing.T, n int) []*ecdsa.PrivateKey {
+	var keys []*ecdsa.PrivateKey
+	for i := 0; i < n; i++ {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func validatorBytes(keys []*ecdsa.PrivateKey) [][]byte {
+	var vals [][]byte
+	for _, key := range keys {
+		vals = append(vals, crypto.PubkeyToAddress(key.PublicKey).Bytes())
+	}
+	return vals
+}
@@ -0,0 +1,275 @@
+// Package conv converts the structs of the IBCHandler binding to the protobuf types of pkg/ibc/core and to the
+// types of ibc-go, and back. The binding structs are what the contracts return and accept, the pkg/ibc/core types
+// are what the contracts commit to, and the ibc-go types are what Cosmos chains use.
+//
+// The conversions keep every field. The enums are converted by their numbers, which are the same in the three
+// representations, and nil slices are kept nil, so a round trip returns an equal value.
+package conv
+
+import (
+	ibcgoclient "github.com/cosmos/ibc-go/v4/modules/core/02-client/types"
+	ibcgoconnection "github.com/cosmos/ibc-go/v4/modules/core/03-connection/types"
+	ibcgochannel "github.com/cosmos/ibc-go/v4/modules/core/04-channel/types"
+	ibcgocommitment "github.com/cosmos/ibc-go/v4/modules/core/23-commitment/types"
+
+	"0fatih/yui-ibc-solidity/pkg/contract/ibchandler"
+	channeltypes "0fatih/yui-ibc-solidity/pkg/ibc/core/channel"
+	ibcclient "0fatih/yui-ibc-solidity/pkg/ibc/core/client"
+	commitmenttypes "0fatih/yui-ibc-solidity/pkg/ibc/core/commitment"
+	connectiontypes "0fatih/yui-ibc-solidity/pkg/ibc/core/connection"
+)
+
+// mapSlice converts the elements of the slice with f. It returns nil for a nil slice.
+func mapSlice[T, U any](s []T, f func(T) U) []U {
+	if s == nil {
+		return nil
+	}
+	r := make([]U, len(s))
+	for i, v := range s {
+		r[i] = f(v)
+	}
+	return r
+}
+
+// Height
+
+func HeightToPB(h ibchandler.HeightData) ibcclient.Height {
+	return ibcclient.NewHeightFromCallData(h)
+}
+
+func HeightFromPB(h ibcclient.Height) ibchandler.HeightData {
+	return h.ToCallData()
+}
+
+func HeightToIBCGo(h ibchandler.HeightData) ibcgoclient.Height {
+	return ibcgoclient.NewHeight(h.RevisionNumber, h.RevisionHeight)
+}
+
+func HeightFromIBCGo(h ibcgoclient.Height) ibchandler.HeightData {
+	return ibchandler.HeightData{
+		RevisionNumber: h.RevisionNumber,
+		RevisionHeight: h.RevisionHeight,
+	}
+}
+
+// MerklePrefix
+
+func MerklePrefixToPB(p ibchandler.MerklePrefixData) commitmenttypes.MerklePrefix {
+	return commitmenttypes.MerklePrefix{KeyPrefix: p.KeyPrefix}
+}
+
+func MerklePrefixFromPB(p commitmenttypes.MerklePrefix) ibchandler.MerklePrefixData {
+	return ibchandler.MerklePrefixData{KeyPrefix: p.KeyPrefix}
+}
+
+func MerklePrefixToIBCGo(p ibchandler.MerklePrefixData) ibcgocommitment.MerklePrefix {
+	return ibcgocommitment.MerklePrefix{KeyPrefix: p.KeyPrefix}
+}
+
+func MerklePrefixFromIBCGo(p ibcgocommitment.MerklePrefix) ibchandler.MerklePrefixData {
+	return ibchandler.MerklePrefixData{KeyPrefix: p.KeyPrefix}
+}
+
+// Connection
+
+func VersionToPB(v ibchandler.VersionData) *connectiontypes.Version {
+	return &connectiontypes.Version{Identifier: v.Identifier, Features: v.Features}
+}
+
+func VersionFromPB(v *connectiontypes.Version) ibchandler.VersionData {
+	return ibchandler.VersionData{Identifier: v.Identifier, Features: v.Features}
+}
+
+func VersionToIBCGo(v ibchandler.VersionData) *ibcgoconnection.Version {
+	return &ibcgoconnection.Version{Identifier: v.Identifier, Features: v.Features}
+}
+
+func VersionFromIBCGo(v *ibcgoconnection.Version) ibchandler.VersionData {
+	return ibchandler.VersionData{Identifier: v.Identifier, Features: v.Features}
+}
+
+func CounterpartyToPB(c ibchandler.CounterpartyData) connectiontypes.Counterparty {
+	return connectiontypes.Counterparty{
+		ClientId:     c.ClientId,
+		ConnectionId: c.ConnectionId,
+		Prefix:       MerklePrefixToPB(c.Prefix),
+	}
+}
+
+func CounterpartyFromPB(c connectiontypes.Counterparty) ibchandler.CounterpartyData {
+	return ibchandler.CounterpartyData{
+		ClientId:     c.ClientId,
+		ConnectionId: c.ConnectionId,
+		Prefix:       MerklePrefixFromPB(c.Prefix),
+	}
+}
+
+func CounterpartyToIBCGo(c ibchandler.CounterpartyData) ibcgoconnection.Counterparty {
+	return ibcgoconnection.Counterparty{
+		ClientId:     c.ClientId,
+		ConnectionId: c.ConnectionId,
+		Prefix:       MerklePrefixToIBCGo(c.Prefix),
+	}
+}
+
+func CounterpartyFromIBCGo(c ibcgoconnection.Counterparty) ibchandler.CounterpartyData {
+	return ibchandler.CounterpartyData{
+		ClientId:     c.ClientId,
+		ConnectionId: c.ConnectionId,
+		Prefix:       MerklePrefixFromIBCGo(c.Prefix),
+	}
+}
+
+// ConnectionEndToPB returns the connection that the IBCHandler commits to.
+func ConnectionEndToPB(conn ibchandler.ConnectionEndData) *connectiontypes.ConnectionEnd {
+	return &connectiontypes.ConnectionEnd{
+		ClientId:     conn.ClientId,
+		Versions:     mapSlice(conn.Versions, VersionToPB),
+		State:        connectiontypes.ConnectionEnd_State(conn.State),
+		Counterparty: CounterpartyToPB(conn.Counterparty),
+		DelayPeriod:  conn.DelayPeriod,
+	}
+}
+
+func ConnectionEndFromPB(conn *connectiontypes.ConnectionEnd) ibchandler.ConnectionEndData {
+	return ibchandler.ConnectionEndData{
+		ClientId:     conn.ClientId,
+		Versions:     mapSlice(conn.Versions, VersionFromPB),
+		State:        uint8(conn.State),
+		Counterparty: CounterpartyFromPB(conn.Counterparty),
+		DelayPeriod:  conn.DelayPeriod,
+	}
+}
+
+func ConnectionEndToIBCGo(conn ibchandler.ConnectionEndData) ibcgoconnection.ConnectionEnd {
+	return ibcgoconnection.ConnectionEnd{
+		ClientId:     conn.ClientId,
+		Versions:     mapSlice(conn.Versions, VersionToIBCGo),
+		State:        ibcgoconnection.State(conn.State),
+		Counterparty: CounterpartyToIBCGo(conn.Counterparty),
+		DelayPeriod:  conn.DelayPeriod,
+	}
+}
+
+func ConnectionEndFromIBCGo(conn ibcgoconnection.ConnectionEnd) ibchandler.ConnectionEndData {
+	return ibchandler.ConnectionEndData{
+		ClientId:     conn.ClientId,
+		Versions:     mapSlice(conn.Versions, VersionFromIBCGo),
+		State:        uint8(conn.State),
+		Counterparty: CounterpartyFromIBCGo(conn.Counterparty),
+		DelayPeriod:  conn.DelayPeriod,
+	}
+}
+
+// Channel
+
+func ChannelCounterpartyToPB(c ibchandler.ChannelCounterpartyData) channeltypes.Channel_Counterparty {
+	return channeltypes.Channel_Counterparty{PortId: c.PortId, ChannelId: c.ChannelId}
+}
+
+func ChannelCounterpartyFromPB(c channeltypes.Channel_Counterparty) ibchandler.ChannelCounterpartyData {
+	return ibchandler.ChannelCounterpartyData{PortId: c.PortId, ChannelId: c.ChannelId}
+}
+
+func ChannelCounterpartyToIBCGo(c ibchandler.ChannelCounterpartyData) ibcgochannel.Counterparty {
+	return ibcgochannel.NewCounterparty(c.PortId, c.ChannelId)
+}
+
+func ChannelCounterpartyFromIBCGo(c ibcgochannel.Counterparty) ibchandler.ChannelCounterpartyData {
+	return ibchandler.ChannelCounterpartyData{PortId: c.PortId, ChannelId: c.ChannelId}
+}
+
+// ChannelToPB returns the channel that the IBCHandler commits to.
+func ChannelToPB(ch ibchandler.ChannelData) *channeltypes.Channel {
+	return &channeltypes.Channel{
+		State:          channeltypes.Channel_State(ch.State),
+		Ordering:       channeltypes.Channel_Order(ch.Ordering),
+		Counterparty:   ChannelCounterpartyToPB(ch.Counterparty),
+		ConnectionHops: ch.ConnectionHops,
+		Version:        ch.Version,
+	}
+}
+
+func ChannelFromPB(ch *channeltypes.Channel) ibchandler.ChannelData {
+	return ibchandler.ChannelData{
+		State:          uint8(ch.State),
+		Ordering:       uint8(ch.Ordering),
+		Counterparty:   ChannelCounterpartyFromPB(ch.Counterparty),
+		ConnectionHops: ch.ConnectionHops,
+		Version:        ch.Version,
+	}
+}
+
+func ChannelToIBCGo(ch ibchandler.ChannelData) ibcgochannel.Channel {
+	return ibcgochannel.Channel{
+		State:          ibcgochannel.State(ch.State),
+		Ordering:       ibcgochannel.Order(ch.Ordering),
+		Counterparty:   ChannelCounterpartyToIBCGo(ch.Counterparty),
+		ConnectionHops: ch.ConnectionHops,
+		Version:        ch.Version,
+	}
+}
+
+func ChannelFromIBCGo(ch ibcgochannel.Channel) ibchandler.ChannelData {
+	return ibchandler.ChannelData{
+		State:          uint8(ch.State),
+		Ordering:       uint8(ch.Ordering),
+		Counterparty:   ChannelCounterpartyFromIBCGo(ch.Counterparty),
+		ConnectionHops: ch.ConnectionHops,
+		Version:        ch.Version,
+	}
+}
+
+// Packet
+
+func PacketToPB(p ibchandler.PacketData) channeltypes.Packet {
+	return channeltypes.Packet{
+		Sequence:           p.Sequence,
+		SourcePort:         p.SourcePort,
+		SourceChannel:      p.SourceChannel,
+		DestinationPort:    p.DestinationPort,
+		DestinationChannel: p.DestinationChannel,
+		Data:               p.Data,
+		TimeoutHeight:      HeightToPB(p.TimeoutHeight),
+		TimeoutTimestamp:   p.TimeoutTimestamp,
+	}
+}
+
+func PacketFromPB(p channeltypes.Packet) ibchandler.PacketData {
+	return ibchandler.PacketData{
+		Sequence:           p.Sequence,
+		SourcePort:         p.SourcePort,
+		SourceChannel:      p.SourceChannel,
+		DestinationPort:    p.DestinationPort,
+		DestinationChannel: p.DestinationChannel,
+		Data:               p.Data,
+		TimeoutHeight:      HeightFromPB(p.TimeoutHeight),
+		TimeoutTimestamp:   p.TimeoutTimestamp,
+	}
+}
+
+func PacketToIBCGo(p ibchandler.PacketData) ibcgochannel.Packet {
+	return ibcgochannel.Packet{
+		Sequence:           p.Sequence,
+		SourcePort:         p.SourcePort,
+		SourceChannel:      p.SourceChannel,
+		DestinationPort:    p.DestinationPort,
+		DestinationChannel: p.DestinationChannel,
+		Data:               p.Data,
+		TimeoutHeight:      HeightToIBCGo(p.TimeoutHeight),
+		TimeoutTimestamp:   p.TimeoutTimestamp,
+	}
+}
+
+func PacketFromIBCGo(p ibcgochannel.Packet) ibchandler.PacketData {
+	return ibchandler.PacketData{
+		Sequence:           p.Sequence,
+		SourcePort:         p.SourcePort,
+		SourceChannel:      p.SourceChannel,
+		DestinationPort:    p.DestinationPort,
+		DestinationChannel: p.DestinationChannel,
+		Data:               p.Data,
+		TimeoutHeight:      HeightFromIBCGo(p.TimeoutHeight),
+		TimeoutTimestamp:   p.TimeoutTimestamp,
+	}
+}
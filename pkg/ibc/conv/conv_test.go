@@ -0,0 +1,93 @@
+package conv
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	"0fatih/yui-ibc-solidity/pkg/contract/ibchandler"
+)
+
+var (
+	testConnection = ibchandler.ConnectionEndData{
+		ClientId: "mock-client-0",
+		Versions: []ibchandler.VersionData{
+			{Identifier: "1", Features: []string{"ORDER_ORDERED", "ORDER_UNORDERED"}},
+		},
+		State: 3,
+		Counterparty: ibchandler.CounterpartyData{
+			ClientId:     "07-tendermint-1",
+			ConnectionId: "connection-2",
+			Prefix:       ibchandler.MerklePrefixData{KeyPrefix: []byte("ibc")},
+		},
+		DelayPeriod: 3_000_000_000,
+	}
+	testChannel = ibchandler.ChannelData{
+		State:          4,
+		Ordering:       2,
+		Counterparty:   ibchandler.ChannelCounterpartyData{PortId: "transfer", ChannelId: "channel-3"},
+		ConnectionHops: []string{"connection-0"},
+		Version:        "ics20-1",
+	}
+	testPacket = ibchandler.PacketData{
+		Sequence:           5,
+		SourcePort:         "transfer",
+		SourceChannel:      "channel-0",
+		DestinationPort:    "transfer",
+		DestinationChannel: "channel-3",
+		Data:               []byte("data"),
+		TimeoutHeight:      ibchandler.HeightData{RevisionNumber: 1, RevisionHeight: 100},
+		TimeoutTimestamp:   1_700_000_000_000_000_000,
+	}
+)
+
+func TestConnectionEnd(t *testing.T) {
+	pb := ConnectionEndToPB(testConnection)
+	require.Equal(t, testConnection, ConnectionEndFromPB(pb))
+	ibcgo := ConnectionEndToIBCGo(testConnection)
+	require.Equal(t, testConnection, ConnectionEndFromIBCGo(ibcgo))
+	require.NoError(t, ibcgo.ValidateBasic())
+
+	// the commitment of the IBCHandler is the same as the one of ibc-go
+	bz, err := proto.Marshal(pb)
+	require.NoError(t, err)
+	ibcgoBz, err := proto.Marshal(&ibcgo)
+	require.NoError(t, err)
+	require.Equal(t, ibcgoBz, bz)
+
+	// an empty connection is kept empty
+	require.Equal(t, ibchandler.ConnectionEndData{}, ConnectionEndFromPB(ConnectionEndToPB(ibchandler.ConnectionEndData{})))
+	require.Equal(t, ibchandler.ConnectionEndData{}, ConnectionEndFromIBCGo(ConnectionEndToIBCGo(ibchandler.ConnectionEndData{})))
+}
+
+func TestChannel(t *testing.T) {
+	pb := ChannelToPB(testChannel)
+	require.Equal(t, testChannel, ChannelFromPB(pb))
+	ibcgo := ChannelToIBCGo(testChannel)
+	require.Equal(t, testChannel, ChannelFromIBCGo(ibcgo))
+	require.NoError(t, ibcgo.ValidateBasic())
+
+	bz, err := proto.Marshal(pb)
+	require.NoError(t, err)
+	ibcgoBz, err := proto.Marshal(&ibcgo)
+	require.NoError(t, err)
+	require.Equal(t, ibcgoBz, bz)
+}
+
+func TestPacket(t *testing.T) {
+	pb := PacketToPB(testPacket)
+	require.Equal(t, testPacket, PacketFromPB(pb))
+	ibcgo := PacketToIBCGo(testPacket)
+	require.Equal(t, testPacket, PacketFromIBCGo(ibcgo))
+	require.NoError(t, ibcgo.ValidateBasic())
+
+	bz, err := proto.Marshal(&pb)
+	require.NoError(t, err)
+	ibcgoBz, err := proto.Marshal(&ibcgo)
+	require.NoError(t, err)
+	require.Equal(t, ibcgoBz, bz)
+
+	require.Equal(t, testPacket.TimeoutHeight, HeightFromPB(HeightToPB(testPacket.TimeoutHeight)))
+	require.Equal(t, testPacket.TimeoutHeight, HeightFromIBCGo(HeightToIBCGo(testPacket.TimeoutHeight)))
+}
@@ -1,7 +1,11 @@
 package client
 
 import (
+	"fmt"
 	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"0fatih/yui-ibc-solidity/pkg/contract/ibchandler"
 )
@@ -10,27 +14,145 @@ import (
 const (
 	// IBFT2 Client
 	BesuIBFT2Client = "hyperledger-besu-ibft2"
+	// QBFT Client
+	// NOTE: This repository does not provide a client contract for QBFT.
+	BesuQBFTClient = "hyperledger-besu-qbft"
 	// NOTE: The mock client is only intended for use in development such as ganache.
 	MockClient = "mock-client"
 )
 
-func NewHeightFromBN(n *big.Int) Height {
-	return Height{
-		RevisionNumber: 0,
-		RevisionHeight: n.Uint64(),
+// the same format as ibc-go: the revision number follows the last dash and must not start with 0
+var revisionFormatRegex = regexp.MustCompile(`^.*[^\n-]-{1}[1-9][0-9]*$`)
+
+// IsRevisionFormat returns true if the chain ID is in the format of `{chainID}-{revision number}`,
+// e.g. "ibc-1". The revision number must not have leading zeros.
+func IsRevisionFormat(chainID string) bool {
+	return revisionFormatRegex.MatchString(chainID)
+}
+
+// ParseChainID returns the revision number of the chain ID. It returns 0 if the chain ID is not in the revision format.
+func ParseChainID(chainID string) uint64 {
+	if !IsRevisionFormat(chainID) {
+		return 0
+	}
+	splitStr := strings.Split(chainID, "-")
+	// the regex ensures that the revision number is a positive integer
+	revision, err := strconv.ParseUint(splitStr[len(splitStr)-1], 10, 64)
+	if err != nil {
+		return 0
 	}
+	return revision
 }
 
-func (h *Height) ToBN() *big.Int {
-	if h.RevisionNumber != 0 {
-		panic("revision number must be zero")
+// SetRevisionNumber returns the chain ID with the revision number replaced.
+func SetRevisionNumber(chainID string, revision uint64) (string, error) {
+	if !IsRevisionFormat(chainID) {
+		return "", fmt.Errorf("chain ID is not in the revision format: %v", chainID)
 	}
-	return big.NewInt(int64(h.RevisionHeight))
+	splitStr := strings.Split(chainID, "-")
+	splitStr[len(splitStr)-1] = strconv.FormatUint(revision, 10)
+	return strings.Join(splitStr, "-"), nil
+}
+
+func NewHeight(revisionNumber, revisionHeight uint64) Height {
+	return Height{
+		RevisionNumber: revisionNumber,
+		RevisionHeight: revisionHeight,
+	}
+}
+
+// NewHeightFromBN returns the height of the block number in the revision 0.
+func NewHeightFromBN(n *big.Int) Height {
+	return NewHeight(0, n.Uint64())
+}
+
+// NewHeightFromRevisionAndBN returns the height of the block number in the revision.
+func NewHeightFromRevisionAndBN(revisionNumber uint64, n *big.Int) Height {
+	return NewHeight(revisionNumber, n.Uint64())
+}
+
+func NewHeightFromCallData(h ibchandler.HeightData) Height {
+	return NewHeight(h.RevisionNumber, h.RevisionHeight)
 }
 
-func (h *Height) ToCallData() ibchandler.HeightData {
+// ToBN returns the block number of the height. The revision number is dropped
+// because the block numbers of a chain do not have revisions.
+func (h Height) ToBN() *big.Int {
+	return new(big.Int).SetUint64(h.RevisionHeight)
+}
+
+func (h Height) ToCallData() ibchandler.HeightData {
 	return ibchandler.HeightData{
 		RevisionNumber: h.RevisionNumber,
 		RevisionHeight: h.RevisionHeight,
 	}
 }
+
+// Compare returns -1, 0 or 1 if the height is less than, equal to or greater than the other.
+// The revision number is compared first.
+func (h Height) Compare(other Height) int {
+	switch {
+	case h.LT(other):
+		return -1
+	case h == other:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// Increment returns the next height in the same revision.
+func (h Height) Increment() Height {
+	return NewHeight(h.RevisionNumber, h.RevisionHeight+1)
+}
+
+// Decrement returns the previous height in the same revision. It returns false if the revision height is 0.
+func (h Height) Decrement() (Height, bool) {
+	if h.RevisionHeight == 0 {
+		return Height{}, false
+	}
+	return NewHeight(h.RevisionNumber, h.RevisionHeight-1), true
+}
+
+// Format returns the height in the format of `{revision number}-{revision height}`.
+func (h Height) Format() string {
+	return fmt.Sprintf("%d-%d", h.RevisionNumber, h.RevisionHeight)
+}
+
+// ParseHeight parses the height in the format of `{revision number}-{revision height}`.
+func ParseHeight(s string) (Height, error) {
+	splitStr := strings.Split(s, "-")
+	if len(splitStr) != 2 {
+		return Height{}, fmt.Errorf("expected height string format: {revision}-{height}. Got: %s", s)
+	}
+	revisionNumber, err := strconv.ParseUint(splitStr[0], 10, 64)
+	if err != nil {
+		return Height{}, fmt.Errorf("invalid revision number: %w", err)
+	}
+	revisionHeight, err := strconv.ParseUint(splitStr[1], 10, 64)
+	if err != nil {
+		return Height{}, fmt.Errorf("invalid revision height: %w", err)
+	}
+	return NewHeight(revisionNumber, revisionHeight), nil
+}
+
+func (h Height) IsZero() bool {
+	return h.RevisionNumber == 0 && h.RevisionHeight == 0
+}
+
+func (h Height) LT(other Height) bool {
+	return h.RevisionNumber < other.RevisionNumber ||
+		(h.RevisionNumber == other.RevisionNumber && h.RevisionHeight < other.RevisionHeight)
+}
+
+func (h Height) LTE(other Height) bool {
+	return h.LT(other) || h == other
+}
+
+func (h Height) GT(other Height) bool {
+	return other.LT(h)
+}
+
+func (h Height) GTE(other Height) bool {
+	return other.LTE(h)
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChainID(t *testing.T) {
+	cases := []struct {
+		chainID  string
+		revision uint64
+		format   bool
+	}{
+		{"1337", 0, false},
+		{"ibc0", 0, false},
+		{"ibc0-1", 1, true},
+		{"a-b-12", 12, true},
+		{"ibc-0", 0, false},
+		{"ibc-01", 0, false},
+		{"-1", 0, false},
+		{"ibc--1", 0, false},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.format, IsRevisionFormat(c.chainID), c.chainID)
+		require.Equal(t, c.revision, ParseChainID(c.chainID), c.chainID)
+	}
+
+	chainID, err := SetRevisionNumber("ibc0-1", 2)
+	require.NoError(t, err)
+	require.Equal(t, "ibc0-2", chainID)
+	_, err = SetRevisionNumber("1337", 2)
+	require.Error(t, err)
+}
+
+func TestHeight(t *testing.T) {
+	h := NewHeightFromRevisionAndBN(1, big.NewInt(100))
+	require.Equal(t, Height{RevisionNumber: 1, RevisionHeight: 100}, h)
+	require.Equal(t, big.NewInt(100), h.ToBN())
+	require.Equal(t, h, NewHeightFromCallData(h.ToCallData()))
+
+	require.Equal(t, 0, h.Compare(NewHeight(1, 100)))
+	require.Equal(t, -1, h.Compare(NewHeight(1, 101)))
+	require.Equal(t, -1, h.Compare(NewHeight(2, 1)))
+	require.Equal(t, 1, h.Compare(NewHeight(0, 1000)))
+	require.True(t, h.LT(h.Increment()))
+	prev, ok := h.Decrement()
+	require.True(t, ok)
+	require.Equal(t, NewHeight(1, 99), prev)
+	_, ok = NewHeight(1, 0).Decrement()
+	require.False(t, ok)
+
+	parsed, err := ParseHeight(h.Format())
+	require.NoError(t, err)
+	require.Equal(t, h, parsed)
+	_, err = ParseHeight("100")
+	require.Error(t, err)
+}
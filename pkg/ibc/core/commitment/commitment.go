@@ -1,40 +1,59 @@
 package commitment
 
 import (
-	host "github.com/cosmos/ibc-go/v4/modules/core/24-host"
 	"github.com/cosmos/ibc-go/v4/modules/core/exported"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
-// This value is determined by IBCHost.sol
-var ibcHostCommitmentSlot = [32]byte{} // uint256(0)
-
 // Slot calculator
+//
+// The functions calculate the slots with DefaultLayout. Please use the methods of the Layout of a chain if its
+// IBCHandler may declare the commitments mapping at another slot.
 
 func ClientStateCommitmentSlot(clientID string) string {
-	return CalculateCommitmentSlot(host.FullClientStateKey(clientID))
+	return DefaultLayout.ClientStateCommitmentSlot(clientID)
 }
 
 func ConsensusStateCommitmentSlot(clientID string, height exported.Height) string {
-	return CalculateCommitmentSlot(host.FullConsensusStateKey(clientID, height))
+	return DefaultLayout.ConsensusStateCommitmentSlot(clientID, height)
 }
 
 func ConnectionStateCommitmentSlot(connectionID string) string {
-	return CalculateCommitmentSlot(host.ConnectionKey(connectionID))
+	return DefaultLayout.ConnectionStateCommitmentSlot(connectionID)
 }
 
 func ChannelStateCommitmentSlot(portID, channelID string) string {
-	return CalculateCommitmentSlot(host.ChannelKey(portID, channelID))
+	return DefaultLayout.ChannelStateCommitmentSlot(portID, channelID)
 }
 
 func PacketCommitmentSlot(portID, channelID string, sequence uint64) string {
-	return CalculateCommitmentSlot(host.PacketCommitmentKey(portID, channelID, sequence))
+	return DefaultLayout.PacketCommitmentSlot(portID, channelID, sequence)
 }
 
 func PacketAcknowledgementCommitmentSlot(portID, channelID string, sequence uint64) string {
-	return CalculateCommitmentSlot(host.PacketAcknowledgementKey(portID, channelID, sequence))
+	return DefaultLayout.PacketAcknowledgementCommitmentSlot(portID, channelID, sequence)
+}
+
+func PacketReceiptCommitmentSlot(portID, channelID string, sequence uint64) string {
+	return DefaultLayout.PacketReceiptCommitmentSlot(portID, channelID, sequence)
+}
+
+func NextSequenceRecvCommitmentSlot(portID, channelID string) string {
+	return DefaultLayout.NextSequenceRecvCommitmentSlot(portID, channelID)
 }
 
 func CalculateCommitmentSlot(path []byte) string {
-	return crypto.Keccak256Hash(crypto.Keccak256Hash(path).Bytes(), ibcHostCommitmentSlot[:]).Hex()
+	return DefaultLayout.CalculateCommitmentSlot(path)
+}
+
+// CalculateCommitmentSlotAt returns the storage slot of `commitments[keccak256(path)]` where the commitments
+// mapping is at `commitmentSlot`.
+func CalculateCommitmentSlotAt(commitmentSlot common.Hash, path []byte) common.Hash {
+	return crypto.Keccak256Hash(crypto.Keccak256Hash(path).Bytes(), commitmentSlot[:])
+}
+
+// PortCapabilitySlot returns the storage slot of `capabilities[portCapabilityPath(portID)][index]` with DefaultLayout.
+func PortCapabilitySlot(portID string, index uint64) string {
+	return DefaultLayout.PortCapabilitySlot(portID, index)
 }
@@ -0,0 +1,686 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: core/23-commitment/ethereum/StorageProof.proto
+
+package ethereum
+
+import (
+	fmt "fmt"
+	_ "github.com/datachainlab/solidity-protobuf/protobuf-solidity/src/protoc/go"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// StorageProof proves a commitment of the IBCHost contract against the state root of a block.
+// The commitment is stored at keccak256(keccak256(path) ++ commitment_slot) in the storage of the contract.
+type StorageProof struct {
+	// address of the IBCHost contract
+	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// storage slot of the commitments mapping in the IBCHost contract
+	CommitmentSlot []byte `protobuf:"bytes,2,opt,name=commitment_slot,json=commitmentSlot,proto3" json:"commitment_slot,omitempty"`
+	// ICS-24 path of the commitment
+	Path []byte `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	// account of the IBCHost contract
+	Nonce uint64 `protobuf:"varint,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// big-endian balance of the account
+	Balance     []byte `protobuf:"bytes,5,opt,name=balance,proto3" json:"balance,omitempty"`
+	CodeHash    []byte `protobuf:"bytes,6,opt,name=code_hash,json=codeHash,proto3" json:"code_hash,omitempty"`
+	StorageHash []byte `protobuf:"bytes,7,opt,name=storage_hash,json=storageHash,proto3" json:"storage_hash,omitempty"`
+	// RLP-encoded list of the trie nodes from the state root to the account
+	AccountProof []byte `protobuf:"bytes,8,opt,name=account_proof,json=accountProof,proto3" json:"account_proof,omitempty"`
+	// RLP-encoded list of the trie nodes from the storage hash to the commitment
+	StorageProof []byte `protobuf:"bytes,9,opt,name=storage_proof,json=storageProof,proto3" json:"storage_proof,omitempty"`
+}
+
+func (m *StorageProof) Reset()         { *m = StorageProof{} }
+func (m *StorageProof) String() string { return proto.CompactTextString(m) }
+func (*StorageProof) ProtoMessage()    {}
+func (*StorageProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb8fea81d086f404, []int{0}
+}
+func (m *StorageProof) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StorageProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StorageProof.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StorageProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageProof.Merge(m, src)
+}
+func (m *StorageProof) XXX_Size() int {
+	return m.Size()
+}
+func (m *StorageProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageProof proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*StorageProof)(nil), "ibc.core.commitment.ethereum.v1.StorageProof")
+}
+
+func init() {
+	proto.RegisterFile("core/23-commitment/ethereum/StorageProof.proto", fileDescriptor_cb8fea81d086f404)
+}
+
+var fileDescriptor_cb8fea81d086f404 = []byte{
+	// 384 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x52, 0xbd, 0xae, 0xd3, 0x30,
+	0x14, 0x4e, 0x2e, 0xbd, 0xf7, 0xb6, 0x26, 0x05, 0xc9, 0xea, 0x60, 0x15, 0x29, 0x94, 0x76, 0xa0,
+	0x4b, 0x62, 0x68, 0x19, 0x18, 0x11, 0x13, 0x23, 0x6a, 0x37, 0x96, 0xca, 0x71, 0xdc, 0xc4, 0x22,
+	0xc9, 0x89, 0x6c, 0x07, 0xd1, 0x95, 0x07, 0x40, 0xbc, 0x01, 0x4f, 0xc1, 0x3b, 0x74, 0xec, 0xc8,
+	0x08, 0xed, 0x8b, 0xa0, 0xd8, 0x8d, 0x5a, 0x89, 0xbb, 0xf9, 0xfb, 0x3b, 0x9f, 0x7d, 0x64, 0x14,
+	0x73, 0x50, 0x82, 0x2e, 0x96, 0x11, 0x87, 0xb2, 0x94, 0xa6, 0x14, 0x95, 0xa1, 0xc2, 0xe4, 0x42,
+	0x89, 0xa6, 0xa4, 0x6b, 0x03, 0x8a, 0x65, 0xe2, 0xa3, 0x02, 0xd8, 0xc6, 0xb5, 0x02, 0x03, 0xf8,
+	0xb9, 0x4c, 0xb8, 0xcd, 0xc4, 0x97, 0x40, 0xdc, 0x05, 0xe2, 0x2f, 0xaf, 0xc7, 0xa3, 0x0c, 0x32,
+	0xb0, 0x5e, 0xda, 0x9e, 0x5c, 0x6c, 0x3c, 0xd5, 0x50, 0xc8, 0x54, 0x9a, 0x5d, 0x64, 0x71, 0xd2,
+	0x6c, 0x23, 0xf1, 0xd5, 0x88, 0x4a, 0x4b, 0xa8, 0xb4, 0xf3, 0x4c, 0x7f, 0xde, 0xa0, 0xe0, 0xba,
+	0x11, 0x13, 0x74, 0xcf, 0xd2, 0x54, 0x09, 0xad, 0x89, 0x3f, 0xf1, 0xe7, 0xc1, 0xaa, 0x83, 0xf8,
+	0x25, 0x7a, 0x7a, 0xa9, 0xdf, 0xe8, 0x02, 0x0c, 0xb9, 0xb1, 0x8e, 0x27, 0x17, 0x7a, 0x5d, 0x80,
+	0xc1, 0x18, 0xf5, 0x6a, 0x66, 0x72, 0xf2, 0xc8, 0xaa, 0xf6, 0x8c, 0x47, 0xe8, 0xb6, 0x82, 0x8a,
+	0x0b, 0xd2, 0x9b, 0xf8, 0xf3, 0xde, 0xca, 0x81, 0xb6, 0x2c, 0x61, 0x05, 0x6b, 0xf9, 0x5b, 0x57,
+	0x76, 0x86, 0xf8, 0x19, 0x1a, 0x70, 0x48, 0xc5, 0x26, 0x67, 0x3a, 0x27, 0x77, 0x56, 0xeb, 0xb7,
+	0xc4, 0x07, 0xa6, 0x73, 0xfc, 0x02, 0x05, 0xda, 0xdd, 0xd9, 0xe9, 0xf7, 0x56, 0x7f, 0x7c, 0xe6,
+	0xac, 0x65, 0x86, 0x86, 0x8c, 0x73, 0x68, 0x2a, 0xb3, 0xa9, 0xdb, 0x77, 0x91, 0xbe, 0xf5, 0x04,
+	0x67, 0xd2, 0xbd, 0x75, 0x86, 0x86, 0xdd, 0x1c, 0x67, 0x1a, 0x38, 0x93, 0xbe, 0x5a, 0xc8, 0xfb,
+	0xef, 0xfe, 0xb7, 0x5f, 0xe4, 0x0d, 0x5a, 0xbc, 0xcb, 0x77, 0xb5, 0x50, 0x85, 0x48, 0x33, 0xa1,
+	0xa2, 0x82, 0x25, 0x9a, 0xee, 0x1a, 0x19, 0xc9, 0x84, 0x47, 0xdd, 0x9a, 0x29, 0x87, 0xca, 0x28,
+	0xc6, 0x8d, 0xa6, 0x76, 0xb9, 0xfb, 0xbf, 0xa1, 0xb7, 0x3f, 0x86, 0xfe, 0xe1, 0x18, 0xfa, 0x7f,
+	0x8e, 0xa1, 0xff, 0xe3, 0x14, 0x7a, 0x87, 0x53, 0xe8, 0xfd, 0x3e, 0x85, 0xde, 0xa7, 0xb7, 0xaf,
+	0xb6, 0xcc, 0xc8, 0xfc, 0xff, 0x21, 0xf5, 0xe7, 0x8c, 0xca, 0x84, 0x53, 0xfb, 0x47, 0x1e, 0xf8,
+	0x20, 0xc9, 0x9d, 0x1d, 0xbe, 0xfc, 0x37, 0x00, 0x77, 0x59, 0x40, 0xd3, 0x46, 0x02, 0x00, 0x00,
+}
+
+func (m *StorageProof) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StorageProof) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StorageProof) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.StorageProof) > 0 {
+		i -= len(m.StorageProof)
+		copy(dAtA[i:], m.StorageProof)
+		i = encodeVarintStorageProof(dAtA, i, uint64(len(m.StorageProof)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.AccountProof) > 0 {
+		i -= len(m.AccountProof)
+		copy(dAtA[i:], m.AccountProof)
+		i = encodeVarintStorageProof(dAtA, i, uint64(len(m.AccountProof)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if len(m.StorageHash) > 0 {
+		i -= len(m.StorageHash)
+		copy(dAtA[i:], m.StorageHash)
+		i = encodeVarintStorageProof(dAtA, i, uint64(len(m.StorageHash)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.CodeHash) > 0 {
+		i -= len(m.CodeHash)
+		copy(dAtA[i:], m.CodeHash)
+		i = encodeVarintStorageProof(dAtA, i, uint64(len(m.CodeHash)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Balance) > 0 {
+		i -= len(m.Balance)
+		copy(dAtA[i:], m.Balance)
+		i = encodeVarintStorageProof(dAtA, i, uint64(len(m.Balance)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Nonce != 0 {
+		i = encodeVarintStorageProof(dAtA, i, uint64(m.Nonce))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Path) > 0 {
+		i -= len(m.Path)
+		copy(dAtA[i:], m.Path)
+		i = encodeVarintStorageProof(dAtA, i, uint64(len(m.Path)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.CommitmentSlot) > 0 {
+		i -= len(m.CommitmentSlot)
+		copy(dAtA[i:], m.CommitmentSlot)
+		i = encodeVarintStorageProof(dAtA, i, uint64(len(m.CommitmentSlot)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintStorageProof(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintStorageProof(dAtA []byte, offset int, v uint64) int {
+	offset -= sovStorageProof(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *StorageProof) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovStorageProof(uint64(l))
+	}
+	l = len(m.CommitmentSlot)
+	if l > 0 {
+		n += 1 + l + sovStorageProof(uint64(l))
+	}
+	l = len(m.Path)
+	if l > 0 {
+		n += 1 + l + sovStorageProof(uint64(l))
+	}
+	if m.Nonce != 0 {
+		n += 1 + sovStorageProof(uint64(m.Nonce))
+	}
+	l = len(m.Balance)
+	if l > 0 {
+		n += 1 + l + sovStorageProof(uint64(l))
+	}
+	l = len(m.CodeHash)
+	if l > 0 {
+		n += 1 + l + sovStorageProof(uint64(l))
+	}
+	l = len(m.StorageHash)
+	if l > 0 {
+		n += 1 + l + sovStorageProof(uint64(l))
+	}
+	l = len(m.AccountProof)
+	if l > 0 {
+		n += 1 + l + sovStorageProof(uint64(l))
+	}
+	l = len(m.StorageProof)
+	if l > 0 {
+		n += 1 + l + sovStorageProof(uint64(l))
+	}
+	return n
+}
+
+func sovStorageProof(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozStorageProof(x uint64) (n int) {
+	return sovStorageProof(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *StorageProof) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStorageProof
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StorageProof: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StorageProof: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStorageProof
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorageProof
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = append(m.Address[:0], dAtA[iNdEx:postIndex]...)
+			if m.Address == nil {
+				m.Address = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitmentSlot", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStorageProof
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorageProof
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CommitmentSlot = append(m.CommitmentSlot[:0], dAtA[iNdEx:postIndex]...)
+			if m.CommitmentSlot == nil {
+				m.CommitmentSlot = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Path", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStorageProof
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorageProof
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Path = append(m.Path[:0], dAtA[iNdEx:postIndex]...)
+			if m.Path == nil {
+				m.Path = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nonce", wireType)
+			}
+			m.Nonce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Nonce |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Balance", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStorageProof
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorageProof
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Balance = append(m.Balance[:0], dAtA[iNdEx:postIndex]...)
+			if m.Balance == nil {
+				m.Balance = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStorageProof
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorageProof
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CodeHash = append(m.CodeHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.CodeHash == nil {
+				m.CodeHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StorageHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStorageProof
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorageProof
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StorageHash = append(m.StorageHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.StorageHash == nil {
+				m.StorageHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccountProof", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStorageProof
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorageProof
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AccountProof = append(m.AccountProof[:0], dAtA[iNdEx:postIndex]...)
+			if m.AccountProof == nil {
+				m.AccountProof = []byte{}
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StorageProof", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStorageProof
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthStorageProof
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthStorageProof
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StorageProof = append(m.StorageProof[:0], dAtA[iNdEx:postIndex]...)
+			if m.StorageProof == nil {
+				m.StorageProof = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStorageProof(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthStorageProof
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipStorageProof(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowStorageProof
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowStorageProof
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowStorageProof
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthStorageProof
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupStorageProof
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthStorageProof
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthStorageProof        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowStorageProof          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupStorageProof = fmt.Errorf("proto: unexpected end of group")
+)
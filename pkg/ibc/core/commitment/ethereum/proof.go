@@ -0,0 +1,158 @@
+// Package ethereum encodes the state proofs of the IBCHost commitments in a self-describing format that a light
+// client of an Ethereum-compatible chain on another ecosystem, such as a Cosmos chain, can verify. Unlike the raw
+// RLP storage proofs submitted to the Solidity clients, a StorageProof carries the account proof, the path and the
+// slot of the commitments mapping, so it can be verified with the state root of a block only.
+package ethereum
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+
+	"0fatih/yui-ibc-solidity/pkg/client"
+	"0fatih/yui-ibc-solidity/pkg/ibc/core/commitment"
+)
+
+// TypeURL is the type URL of a StorageProof packed in an Any.
+const TypeURL = "/ibc.core.commitment.ethereum.v1.StorageProof"
+
+// NewStorageProof returns the proof of the commitment at the path from StorageProofRLP[index] of the state proof
+// of the IBCHost contract at the address.
+func NewStorageProof(address common.Address, commitmentSlot common.Hash, path []byte, proof *client.StateProof, index int) (*StorageProof, error) {
+	if index < 0 || index >= len(proof.StorageProofRLP) {
+		return nil, fmt.Errorf("storage proof not found: index=%v", index)
+	}
+	return &StorageProof{
+		Address:        address.Bytes(),
+		CommitmentSlot: commitmentSlot.Bytes(),
+		Path:           path,
+		Nonce:          proof.Nonce,
+		Balance:        proof.Balance.Bytes(),
+		CodeHash:       common.CopyBytes(proof.CodeHash[:]),
+		StorageHash:    common.CopyBytes(proof.StorageHash[:]),
+		AccountProof:   proof.AccountProofRLP,
+		StorageProof:   proof.StorageProofRLP[index],
+	}, nil
+}
+
+// ValidateBasic checks the lengths of the fields.
+func (p *StorageProof) ValidateBasic() error {
+	if len(p.Address) != common.AddressLength {
+		return fmt.Errorf("invalid address length: %v", len(p.Address))
+	} else if len(p.CommitmentSlot) != common.HashLength {
+		return fmt.Errorf("invalid commitment slot length: %v", len(p.CommitmentSlot))
+	} else if len(p.Path) == 0 {
+		return fmt.Errorf("empty path")
+	} else if len(p.CodeHash) != common.HashLength {
+		return fmt.Errorf("invalid code hash length: %v", len(p.CodeHash))
+	} else if len(p.StorageHash) != common.HashLength {
+		return fmt.Errorf("invalid storage hash length: %v", len(p.StorageHash))
+	} else if len(p.AccountProof) == 0 {
+		return fmt.Errorf("empty account proof")
+	} else if len(p.StorageProof) == 0 {
+		return fmt.Errorf("empty storage proof")
+	}
+	return nil
+}
+
+// Slot returns the storage slot of the commitment, which is derived in the same way as
+// commitment.CalculateCommitmentSlot.
+func (p *StorageProof) Slot() common.Hash {
+	return commitment.CalculateCommitmentSlotAt(common.BytesToHash(p.CommitmentSlot), p.Path)
+}
+
+// StateProof returns the proof in the format of pkg/client, which has the storage proof of the commitment only.
+func (p *StorageProof) StateProof() *client.StateProof {
+	proof := &client.StateProof{
+		Nonce:           p.Nonce,
+		AccountProofRLP: p.AccountProof,
+		StorageProofRLP: [][]byte{p.StorageProof},
+	}
+	proof.Balance.SetBytes(p.Balance)
+	copy(proof.CodeHash[:], p.CodeHash)
+	copy(proof.StorageHash[:], p.StorageHash)
+	return proof
+}
+
+// MarshalAny returns the proof packed in an Any.
+func MarshalAny(p *StorageProof) ([]byte, error) {
+	bz, err := proto.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(&types.Any{TypeUrl: TypeURL, Value: bz})
+}
+
+// UnmarshalAny returns the proof packed in an Any. It fails if the Any has another type.
+func UnmarshalAny(bz []byte) (*StorageProof, error) {
+	var any types.Any
+	if err := proto.Unmarshal(bz, &any); err != nil {
+		return nil, err
+	} else if any.TypeUrl != TypeURL {
+		return nil, fmt.Errorf("expected %v, but got %v", TypeURL, any.TypeUrl)
+	}
+	var p StorageProof
+	if err := proto.Unmarshal(any.Value, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Verifier verifies StorageProofs of the IBCHost contract that a light client trusts. The address and the
+// commitment slot of a proof must be the ones of the verifier, so a proof cannot refer to another contract or
+// another mapping.
+type Verifier struct {
+	Address        common.Address
+	CommitmentSlot common.Hash
+}
+
+// NewVerifier returns a Verifier of the IBCHost contract at the address whose commitments mapping is at the slot.
+func NewVerifier(address common.Address, commitmentSlot common.Hash) Verifier {
+	return Verifier{Address: address, CommitmentSlot: commitmentSlot}
+}
+
+// VerifyMembership verifies that keccak256(value) is stored at the path in the state of the root.
+func (v Verifier) VerifyMembership(stateRoot common.Hash, proof *StorageProof, path []byte, value []byte) error {
+	stored, found, err := v.verify(stateRoot, proof, path)
+	if err != nil {
+		return err
+	} else if !found {
+		return fmt.Errorf("commitment not found: path=%s", path)
+	} else if expected := crypto.Keccak256Hash(value); stored != expected {
+		return fmt.Errorf("commitment mismatch: path=%s expected=%v actual=%v", path, expected, stored)
+	}
+	return nil
+}
+
+// VerifyNonMembership verifies that nothing is stored at the path in the state of the root.
+func (v Verifier) VerifyNonMembership(stateRoot common.Hash, proof *StorageProof, path []byte) error {
+	stored, found, err := v.verify(stateRoot, proof, path)
+	if err != nil {
+		return err
+	} else if found {
+		return fmt.Errorf("value exists: path=%s value=%v", path, stored)
+	}
+	return nil
+}
+
+// verify verifies the proof of the path and returns the stored value and whether it is found.
+func (v Verifier) verify(stateRoot common.Hash, proof *StorageProof, path []byte) (common.Hash, bool, error) {
+	if err := proof.ValidateBasic(); err != nil {
+		return common.Hash{}, false, err
+	} else if address := common.BytesToAddress(proof.Address); address != v.Address {
+		return common.Hash{}, false, fmt.Errorf("address mismatch: expected=%v actual=%v", v.Address, address)
+	} else if slot := common.BytesToHash(proof.CommitmentSlot); slot != v.CommitmentSlot {
+		return common.Hash{}, false, fmt.Errorf("commitment slot mismatch: expected=%v actual=%v", v.CommitmentSlot, slot)
+	} else if !bytes.Equal(proof.Path, path) {
+		return common.Hash{}, false, fmt.Errorf("path mismatch: expected=%s actual=%s", path, proof.Path)
+	}
+	stateProof := proof.StateProof()
+	if err := stateProof.VerifyAccountProof(stateRoot, v.Address); err != nil {
+		return common.Hash{}, false, err
+	}
+	return stateProof.VerifyStorageProof(0, proof.Slot())
+}
@@ -0,0 +1,123 @@
+package ethereum
+
+import (
+	"math/big"
+	"testing"
+
+	host "github.com/cosmos/ibc-go/v4/modules/core/24-host"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+
+	"0fatih/yui-ibc-solidity/pkg/client"
+	"0fatih/yui-ibc-solidity/pkg/ibc/core/commitment"
+)
+
+func TestStorageProof(t *testing.T) {
+	address := common.HexToAddress("0xaa43d337145E8930d01cb4E60Abf6595C692921E")
+	commitmentSlot := commitment.DefaultCommitmentSlot
+	path := host.ChannelKey("transfer", "channel-0")
+	emptyPath := host.ChannelKey("transfer", "channel-1")
+	value := []byte("channel")
+
+	// the slot is the same as the one used by the relayer
+	slot := commitment.CalculateCommitmentSlotAt(commitmentSlot, path)
+	require.Equal(t, commitment.ChannelStateCommitmentSlot("transfer", "channel-0"), slot.Hex())
+
+	storageTrie := newTrie()
+	bz, err := rlp.EncodeToBytes(crypto.Keccak256(value))
+	require.NoError(t, err)
+	require.NoError(t, storageTrie.Update(crypto.Keccak256(slot.Bytes()), bz))
+
+	account := gethtypes.StateAccount{
+		Nonce:    1,
+		Balance:  big.NewInt(100),
+		Root:     storageTrie.Hash(),
+		CodeHash: crypto.Keccak256([]byte("code")),
+	}
+	stateTrie := newTrie()
+	bz, err = rlp.EncodeToBytes(&account)
+	require.NoError(t, err)
+	require.NoError(t, stateTrie.Update(crypto.Keccak256(address.Bytes()), bz))
+	stateRoot := stateTrie.Hash()
+
+	stateProof := &client.StateProof{
+		Balance:         *account.Balance,
+		CodeHash:        common.BytesToHash(account.CodeHash),
+		Nonce:           account.Nonce,
+		StorageHash:     account.Root,
+		AccountProofRLP: prove(t, stateTrie, crypto.Keccak256(address.Bytes())),
+		StorageProofRLP: [][]byte{
+			prove(t, storageTrie, crypto.Keccak256(slot.Bytes())),
+			prove(t, storageTrie, crypto.Keccak256(commitment.CalculateCommitmentSlotAt(commitmentSlot, emptyPath).Bytes())),
+		},
+	}
+	proof, err := NewStorageProof(address, commitmentSlot, path, stateProof, 0)
+	require.NoError(t, err)
+	require.Equal(t, slot, proof.Slot())
+	nonMembershipProof, err := NewStorageProof(address, commitmentSlot, emptyPath, stateProof, 1)
+	require.NoError(t, err)
+	_, err = NewStorageProof(address, commitmentSlot, path, stateProof, 2)
+	require.Error(t, err)
+
+	// the proof is unchanged by the Any encoding
+	bz, err = MarshalAny(proof)
+	require.NoError(t, err)
+	decoded, err := UnmarshalAny(bz)
+	require.NoError(t, err)
+	require.Equal(t, proof, decoded)
+	_, err = UnmarshalAny(bz[1:])
+	require.Error(t, err)
+
+	verifier := NewVerifier(address, commitmentSlot)
+	require.NoError(t, verifier.VerifyMembership(stateRoot, decoded, path, value))
+	require.NoError(t, verifier.VerifyNonMembership(stateRoot, nonMembershipProof, emptyPath))
+
+	// the value does not match
+	require.Error(t, verifier.VerifyMembership(stateRoot, proof, path, []byte("other")))
+	require.Error(t, verifier.VerifyNonMembership(stateRoot, proof, path))
+	require.Error(t, verifier.VerifyMembership(stateRoot, nonMembershipProof, emptyPath, value))
+	// another path
+	require.Error(t, verifier.VerifyMembership(stateRoot, proof, emptyPath, value))
+	// another state root
+	require.Error(t, verifier.VerifyMembership(common.HexToHash("0x01"), proof, path, value))
+	// another contract
+	require.Error(t, NewVerifier(common.HexToAddress("0x01"), commitmentSlot).VerifyMembership(stateRoot, proof, path, value))
+	// another mapping
+	require.Error(t, NewVerifier(address, common.HexToHash("0x01")).VerifyMembership(stateRoot, proof, path, value))
+
+	// the account fields do not match
+	invalid := *proof
+	invalid.Nonce = 2
+	require.Error(t, verifier.VerifyMembership(stateRoot, &invalid, path, value))
+	invalid = *proof
+	invalid.CodeHash = invalid.CodeHash[1:]
+	require.Error(t, verifier.VerifyMembership(stateRoot, &invalid, path, value))
+}
+
+func newTrie() *trie.Trie {
+	return trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase()))
+}
+
+type proofList []rlp.RawValue
+
+func (l *proofList) Put(key []byte, value []byte) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func (l *proofList) Delete(key []byte) error {
+	panic("not supported")
+}
+
+func prove(t *testing.T, tr *trie.Trie, key []byte) []byte {
+	var nodes proofList
+	require.NoError(t, tr.Prove(key, 0, &nodes))
+	bz, err := rlp.EncodeToBytes(nodes)
+	require.NoError(t, err)
+	return bz
+}
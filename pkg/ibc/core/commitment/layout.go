@@ -0,0 +1,128 @@
+package commitment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	host "github.com/cosmos/ibc-go/v4/modules/core/24-host"
+	"github.com/cosmos/ibc-go/v4/modules/core/exported"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DefaultCommitmentSlot is the storage slot of the commitments mapping of IBCHost.sol, which is determined by the
+// order of the state variables in IBCStore.sol.
+var DefaultCommitmentSlot = common.Hash{} // uint256(0)
+
+// DefaultCapabilitiesSlot is the storage slot of the capabilities mapping of IBCStore.sol.
+var DefaultCapabilitiesSlot = common.BigToHash(big.NewInt(10))
+
+// DefaultLayout is the layout of the IBCHandler of this repository.
+var DefaultLayout = NewLayout(DefaultCommitmentSlot)
+
+// MaxProbedCommitmentSlot is the last slot that DetectCommitmentSlot probes.
+const MaxProbedCommitmentSlot = 64
+
+var (
+	// ErrCommitmentMismatch is returned if the commitment is not stored at the slot of the layout.
+	ErrCommitmentMismatch = errors.New("commitment mismatch")
+	// ErrCommitmentSlotNotFound is returned if none of the probed slots has the commitment.
+	ErrCommitmentSlotNotFound = errors.New("commitment slot not found")
+)
+
+// Layout is the storage layout of the commitments of an IBCHandler. A fork of the handler or another version of
+// IBCStore.sol may declare the commitments mapping at another slot, so the layout is configured per handler.
+// The relayer also reads the capabilities mapping, which is not committed.
+type Layout struct {
+	CommitmentSlot   common.Hash
+	CapabilitiesSlot common.Hash
+}
+
+// NewLayout returns the layout whose commitments mapping is at the slot. The capabilities mapping is at the slot of
+// IBCStore.sol.
+func NewLayout(commitmentSlot common.Hash) Layout {
+	return Layout{
+		CommitmentSlot:   commitmentSlot,
+		CapabilitiesSlot: DefaultCapabilitiesSlot,
+	}
+}
+
+func (l Layout) ClientStateCommitmentSlot(clientID string) string {
+	return l.CalculateCommitmentSlot(host.FullClientStateKey(clientID))
+}
+
+func (l Layout) ConsensusStateCommitmentSlot(clientID string, height exported.Height) string {
+	return l.CalculateCommitmentSlot(host.FullConsensusStateKey(clientID, height))
+}
+
+func (l Layout) ConnectionStateCommitmentSlot(connectionID string) string {
+	return l.CalculateCommitmentSlot(host.ConnectionKey(connectionID))
+}
+
+func (l Layout) ChannelStateCommitmentSlot(portID, channelID string) string {
+	return l.CalculateCommitmentSlot(host.ChannelKey(portID, channelID))
+}
+
+func (l Layout) PacketCommitmentSlot(portID, channelID string, sequence uint64) string {
+	return l.CalculateCommitmentSlot(host.PacketCommitmentKey(portID, channelID, sequence))
+}
+
+func (l Layout) PacketAcknowledgementCommitmentSlot(portID, channelID string, sequence uint64) string {
+	return l.CalculateCommitmentSlot(host.PacketAcknowledgementKey(portID, channelID, sequence))
+}
+
+func (l Layout) PacketReceiptCommitmentSlot(portID, channelID string, sequence uint64) string {
+	return l.CalculateCommitmentSlot(host.PacketReceiptKey(portID, channelID, sequence))
+}
+
+func (l Layout) NextSequenceRecvCommitmentSlot(portID, channelID string) string {
+	return l.CalculateCommitmentSlot(host.NextSequenceRecvKey(portID, channelID))
+}
+
+func (l Layout) CalculateCommitmentSlot(path []byte) string {
+	return CalculateCommitmentSlotAt(l.CommitmentSlot, path).Hex()
+}
+
+// PortCapabilitySlot returns the storage slot of `capabilities[portCapabilityPath(portID)][index]`, which is the
+// address of a module bound to the port.
+func (l Layout) PortCapabilitySlot(portID string, index uint64) string {
+	slot := crypto.Keccak256Hash([]byte(portID), l.CapabilitiesSlot[:])
+	// the elements of a dynamic array start at the hash of its slot
+	start := new(big.Int).SetBytes(crypto.Keccak256(slot[:]))
+	return common.BigToHash(start.Add(start, new(big.Int).SetUint64(index))).Hex()
+}
+
+// StorageReader reads the storage of a contract with eth_getStorageAt. It is implemented by ethclient.Client.
+type StorageReader interface {
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+}
+
+// VerifyCommitment checks that keccak256(value) is stored at the path in the storage of the contract at the block.
+// It fails with ErrCommitmentMismatch if another value is stored.
+func (l Layout) VerifyCommitment(ctx context.Context, reader StorageReader, address common.Address, path, value []byte, blockNumber *big.Int) error {
+	bz, err := reader.StorageAt(ctx, address, CalculateCommitmentSlotAt(l.CommitmentSlot, path), blockNumber)
+	if err != nil {
+		return err
+	}
+	if stored, expected := common.BytesToHash(bz), crypto.Keccak256Hash(value); stored != expected {
+		return fmt.Errorf("%w: commitmentSlot=%v path=%s expected=%v actual=%v", ErrCommitmentMismatch, l.CommitmentSlot, path, expected, stored)
+	}
+	return nil
+}
+
+// DetectCommitmentSlot returns the slot of the commitments mapping of the contract by probing the slots from 0 to
+// MaxProbedCommitmentSlot for the commitment of the value at the path, which must exist at the block.
+// It fails with ErrCommitmentSlotNotFound if none of the slots has the commitment.
+func DetectCommitmentSlot(ctx context.Context, reader StorageReader, address common.Address, path, value []byte, blockNumber *big.Int) (common.Hash, error) {
+	for i := int64(0); i <= MaxProbedCommitmentSlot; i++ {
+		layout := NewLayout(common.BigToHash(big.NewInt(i)))
+		if err := layout.VerifyCommitment(ctx, reader, address, path, value, blockNumber); err == nil {
+			return layout.CommitmentSlot, nil
+		} else if !errors.Is(err, ErrCommitmentMismatch) {
+			return common.Hash{}, err
+		}
+	}
+	return common.Hash{}, fmt.Errorf("%w: address=%v path=%s", ErrCommitmentSlotNotFound, address, path)
+}
@@ -0,0 +1,61 @@
+package commitment
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	host "github.com/cosmos/ibc-go/v4/modules/core/24-host"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+type testStorage map[common.Hash]common.Hash
+
+func (s testStorage) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	if s == nil {
+		return nil, errors.New("unavailable")
+	}
+	return s[key].Bytes(), nil
+}
+
+func TestLayout(t *testing.T) {
+	// the functions use the default layout
+	require.Equal(t, ClientStateCommitmentSlot("mock-client-0"), DefaultLayout.ClientStateCommitmentSlot("mock-client-0"))
+	require.Equal(t, CalculateCommitmentSlot([]byte("path")), CalculateCommitmentSlotAt(DefaultCommitmentSlot, []byte("path")).Hex())
+	require.NotEqual(t, DefaultLayout.ChannelStateCommitmentSlot("transfer", "channel-0"), NewLayout(common.HexToHash("0x01")).ChannelStateCommitmentSlot("transfer", "channel-0"))
+
+	// the capabilities mapping, which is not committed, is at the slot of IBCStore.sol unless it is configured
+	layout := NewLayout(common.HexToHash("0x01"))
+	require.Equal(t, PortCapabilitySlot("transfer", 0), layout.PortCapabilitySlot("transfer", 0))
+	layout.CapabilitiesSlot = common.HexToHash("0x0b")
+	require.NotEqual(t, PortCapabilitySlot("transfer", 0), layout.PortCapabilitySlot("transfer", 0))
+}
+
+func TestDetectCommitmentSlot(t *testing.T) {
+	ctx := context.Background()
+	address := common.HexToAddress("0xaa43d337145E8930d01cb4E60Abf6595C692921E")
+	path := host.FullClientStateKey("mock-client-0")
+	value := []byte("client state")
+
+	// the commitments mapping of a fork is at slot 3
+	layout := NewLayout(common.BigToHash(big.NewInt(3)))
+	storage := testStorage{
+		common.HexToHash(layout.ClientStateCommitmentSlot("mock-client-0")): crypto.Keccak256Hash(value),
+	}
+	require.NoError(t, layout.VerifyCommitment(ctx, storage, address, path, value, nil))
+	require.ErrorIs(t, DefaultLayout.VerifyCommitment(ctx, storage, address, path, value, nil), ErrCommitmentMismatch)
+
+	slot, err := DetectCommitmentSlot(ctx, storage, address, path, value, nil)
+	require.NoError(t, err)
+	require.Equal(t, layout.CommitmentSlot, slot)
+
+	_, err = DetectCommitmentSlot(ctx, storage, address, path, []byte("other"), nil)
+	require.ErrorIs(t, err, ErrCommitmentSlotNotFound)
+	// the error of the reader is returned as is
+	_, err = DetectCommitmentSlot(ctx, testStorage(nil), address, path, value, nil)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrCommitmentSlotNotFound)
+}
@@ -0,0 +1,1427 @@
+// Package relay implements the operations of a relayer on the chains that run the IBC contracts: creating and
+// updating clients, the connection and channel handshakes, and relaying packets and acknowledgements.
+// Every operation returns an error instead of failing a test, so the package can be used by long-running tools.
+// pkg/testing wraps it for the tests.
+package relay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/gogo/protobuf/proto"
+
+	"0fatih/yui-ibc-solidity/pkg/client"
+	"0fatih/yui-ibc-solidity/pkg/contract/erc20"
+	ibccommitment "0fatih/yui-ibc-solidity/pkg/contract/ibccommitmenttesthelper"
+	"0fatih/yui-ibc-solidity/pkg/contract/ibchandler"
+	"0fatih/yui-ibc-solidity/pkg/contract/ics20bank"
+	"0fatih/yui-ibc-solidity/pkg/contract/ics20transferbank"
+	ibft2clienttypes "0fatih/yui-ibc-solidity/pkg/ibc/clients/ibft2"
+	mockclienttypes "0fatih/yui-ibc-solidity/pkg/ibc/clients/mock"
+	"0fatih/yui-ibc-solidity/pkg/ibc/conv"
+	channeltypes "0fatih/yui-ibc-solidity/pkg/ibc/core/channel"
+	ibcclient "0fatih/yui-ibc-solidity/pkg/ibc/core/client"
+	"0fatih/yui-ibc-solidity/pkg/ibc/core/commitment"
+	ethcommitment "0fatih/yui-ibc-solidity/pkg/ibc/core/commitment/ethereum"
+	connectiontypes "0fatih/yui-ibc-solidity/pkg/ibc/core/connection"
+	"0fatih/yui-ibc-solidity/pkg/index"
+	"0fatih/yui-ibc-solidity/pkg/signer"
+)
+
+const (
+	DefaultChannelVersion        = "ics20-1"
+	BlockTime             uint64 = 1000 * 1000 * 1000 // 1[sec]
+	DefaultDelayPeriod    uint64 = 3 * BlockTime
+	DefaultPrefix                = "ibc"
+	TransferPort                 = "transfer"
+
+	RelayerKeyIndex uint32 = 0
+)
+
+var (
+	abiSendPacket,
+	abiRecvPacket,
+	abiWriteAcknowledgement,
+	abiAcknowledgePacket,
+	abiTimeoutPacket abi.Event
+)
+
+func init() {
+	parsedHandlerABI, err := abi.JSON(strings.NewReader(ibchandler.IbchandlerABI))
+	if err != nil {
+		panic(err)
+	}
+	abiSendPacket = parsedHandlerABI.Events["SendPacket"]
+	abiRecvPacket = parsedHandlerABI.Events["RecvPacket"]
+	abiWriteAcknowledgement = parsedHandlerABI.Events["WriteAcknowledgement"]
+	abiAcknowledgePacket = parsedHandlerABI.Events["AcknowledgePacket"]
+	abiTimeoutPacket = parsedHandlerABI.Events["TimeoutPacket"]
+}
+
+type Chain struct {
+	chainID int64
+	// chainIDString is the chain ID used in IBC. It is the decimal chain ID if it is empty.
+	chainIDString string
+	client        *client.ETHClient
+	lc            *LightClient
+	keyring       signer.Keyring
+	signersMtx    sync.Mutex
+	signers       map[uint32]signer.Signer
+	nonces        *client.NonceManager
+
+	ContractConfig ContractConfig
+	// commitmentLayout is the storage layout of the commitments of the IBCHandler
+	commitmentLayout commitment.Layout
+
+	// Core Modules
+	IBCHandler    ibchandler.Ibchandler
+	IBCCommitment ibccommitment.Ibccommitmenttesthelper
+
+	// App Modules
+	ERC20         erc20.Erc20
+	ICS20Transfer ics20transferbank.Ics20transferbank
+	ICS20Bank     ics20bank.Ics20bank
+
+	// State
+	LastLCState LightClientState
+	// EventIndex indexes the events of the IBCHandler. It is kept in memory unless it is replaced with a persisted one.
+	EventIndex *index.Index
+
+	// IBC specific helpers
+	ClientIDs   []string      // ClientID's used on this chain
+	Connections []*Connection // track connectionID's created for this chain
+}
+
+// NewChain returns a Chain of the contracts of the config. The transactions are signed by the signers of the keyring.
+// The commitment slot of the IBCHandler is validated or detected with ResolveCommitmentLayout, and it must be
+// supported by the light client module of the client type.
+func NewChain(ctx context.Context, ethClient *client.ETHClient, lc *LightClient, keyring signer.Keyring, config ContractConfig) (*Chain, error) {
+	chainID, err := ethClient.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	commitmentLayout, err := ResolveCommitmentLayout(ctx, ethClient, config)
+	if err != nil {
+		return nil, err
+	}
+	module, err := GetLightClientModule(lc.ClientType())
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := module.(CommitmentLayoutValidator); ok {
+		if err := v.ValidateCommitmentLayout(commitmentLayout); err != nil {
+			return nil, fmt.Errorf("the client type %v does not support the IBCHandler: %w", lc.ClientType(), err)
+		}
+	}
+	ibcHandler, err := ibchandler.NewIbchandler(config.IBCHandlerAddress, ethClient)
+	if err != nil {
+		return nil, err
+	}
+	ibcCommitment, err := ibccommitment.NewIbccommitmenttesthelper(config.IBCCommitmentTestHelperAddress, ethClient)
+	if err != nil {
+		return nil, err
+	}
+	erc20_, err := erc20.NewErc20(config.ERC20TokenAddress, ethClient)
+	if err != nil {
+		return nil, err
+	}
+	ics20transfer, err := ics20transferbank.NewIcs20transferbank(config.ICS20TransferBankAddress, ethClient)
+	if err != nil {
+		return nil, err
+	}
+	ics20bank, err := ics20bank.NewIcs20bank(config.ICS20BankAddress, ethClient)
+	if err != nil {
+		return nil, err
+	}
+	eventIndex, err := index.NewIndex(config.IBCHandlerAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Chain{
+		client:           ethClient,
+		chainID:          chainID.Int64(),
+		lc:               lc,
+		keyring:          keyring,
+		ContractConfig:   config,
+		commitmentLayout: commitmentLayout,
+		signers:          make(map[uint32]signer.Signer),
+		nonces:           client.NewNonceManager(ethClient),
+
+		IBCHandler:    *ibcHandler,
+		IBCCommitment: *ibcCommitment,
+
+		ERC20:         *erc20_,
+		ICS20Transfer: *ics20transfer,
+		ICS20Bank:     *ics20bank,
+
+		EventIndex: eventIndex,
+	}, nil
+}
+
+func (chain *Chain) Client() *client.ETHClient {
+	return chain.client
+}
+
+// CommitmentLayout returns the storage layout of the commitments of the IBCHandler.
+func (chain *Chain) CommitmentLayout() commitment.Layout {
+	return chain.commitmentLayout
+}
+
+func (chain *Chain) ClientType() string {
+	return chain.lc.ClientType()
+}
+
+// TxOpts returns the options of a transaction of the key. The gas and the fees are decided by the client of the chain.
+// The nonce is assigned by the nonce manager of the chain, so the transaction must be sent or the nonce is released
+// by WaitIfNoError.
+//
+// If the signer of the key is not found, the error is returned when the transaction is signed.
+func (chain *Chain) TxOpts(ctx context.Context, index uint32) *bind.TransactOpts {
+	s, err := chain.Signer(index)
+	if err != nil {
+		return &bind.TransactOpts{
+			Context: ctx,
+			Signer: func(common.Address, *gethtypes.Transaction) (*gethtypes.Transaction, error) {
+				return nil, err
+			},
+		}
+	}
+	opts := signer.TransactOpts(ctx, s, big.NewInt(chain.chainID))
+	if err := chain.client.SetTxFees(ctx, opts); err != nil {
+		// the error is returned when the transaction is signed, so it is never sent
+		opts.Signer = func(common.Address, *gethtypes.Transaction) (*gethtypes.Transaction, error) {
+			return nil, fmt.Errorf("failed to set the fees of a transaction: %w", err)
+		}
+	}
+	// leave the nonce to the backend if it cannot be synced
+	if nonce, err := chain.nonces.Next(ctx, opts.From); err == nil {
+		opts.Nonce = new(big.Int).SetUint64(nonce)
+	}
+	return opts
+}
+
+// CallOpts returns the options of a call from the address of the key. The address is zero if the signer of the key is not found.
+func (chain *Chain) CallOpts(ctx context.Context, index uint32) *bind.CallOpts {
+	opts := &bind.CallOpts{Context: ctx}
+	if s, err := chain.Signer(index); err == nil {
+		opts.From = s.Address()
+	}
+	return opts
+}
+
+// PendingTxs returns the transactions of the key that are sent but whose receipts are not confirmed yet.
+func (chain *Chain) PendingTxs(index uint32) ([]client.PendingTx, error) {
+	address, err := chain.Address(index)
+	if err != nil {
+		return nil, err
+	}
+	return chain.nonces.Pending(address), nil
+}
+
+// Address returns the address of the key.
+func (chain *Chain) Address(index uint32) (common.Address, error) {
+	s, err := chain.Signer(index)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return s.Address(), nil
+}
+
+// Signer returns the signer of the key from the keyring of the chain.
+func (chain *Chain) Signer(index uint32) (signer.Signer, error) {
+	chain.signersMtx.Lock()
+	defer chain.signersMtx.Unlock()
+	if s, ok := chain.signers[index]; ok {
+		return s, nil
+	}
+	s, err := chain.keyring.Signer(index)
+	if err != nil {
+		return nil, err
+	}
+	chain.signers[index] = s
+	return s, nil
+}
+
+// signTx signs the transaction with the signer of the address, which must have been used by TxOpts.
+func (chain *Chain) signTx(address common.Address, tx *gethtypes.Transaction) (*gethtypes.Transaction, error) {
+	chain.signersMtx.Lock()
+	defer chain.signersMtx.Unlock()
+	for _, s := range chain.signers {
+		if s.Address() == address {
+			return s.SignTx(context.Background(), big.NewInt(chain.chainID), tx)
+		}
+	}
+	return nil, fmt.Errorf("no signer for the address: %v", address)
+}
+
+func (chain *Chain) ChainID() int64 {
+	return chain.chainID
+}
+
+func (chain *Chain) ChainIDString() string {
+	if chain.chainIDString != "" {
+		return chain.chainIDString
+	}
+	return fmt.Sprint(chain.chainID)
+}
+
+// SetChainIDString sets the chain ID used in IBC. If the chain ID is in the format of `{name}-{N}`,
+// the heights of the chain have the revision number N, which must be supported by the client type of the chain.
+// It must be called before the header is updated.
+func (chain *Chain) SetChainIDString(chainID string) error {
+	revisionNumber := ibcclient.ParseChainID(chainID)
+	module, err := GetLightClientModule(chain.ClientType())
+	if err != nil {
+		return err
+	}
+	if v, ok := module.(RevisionNumberValidator); ok {
+		if err := v.ValidateRevisionNumber(revisionNumber); err != nil {
+			return fmt.Errorf("the client type %v does not support the chain ID %v: %w", chain.ClientType(), chainID, err)
+		}
+	}
+	chain.chainIDString = chainID
+	chain.lc.SetRevisionNumber(revisionNumber)
+	return nil
+}
+
+// RevisionNumber returns the revision number of the heights of the chain.
+func (chain *Chain) RevisionNumber() uint64 {
+	return chain.lc.RevisionNumber()
+}
+
+func (chain *Chain) GetCommitmentPrefix() []byte {
+	return []byte(DefaultPrefix)
+}
+
+func (chain *Chain) GetIBFT2ClientState(ctx context.Context, clientID string) (*ibft2clienttypes.ClientState, error) {
+	bz, found, err := chain.IBCHandler.GetClientState(chain.CallOpts(ctx, RelayerKeyIndex), clientID)
+	if err != nil {
+		return nil, err
+	} else if !found {
+		return nil, fmt.Errorf("clientState not found: clientID=%v", clientID)
+	}
+	var cs ibft2clienttypes.ClientState
+	if err := UnmarshalWithAny(bz, &cs); err != nil {
+		return nil, err
+	}
+	return &cs, nil
+}
+
+func (chain *Chain) GetIBFT2ConsensusState(ctx context.Context, clientID string, height ibcclient.Height) (*ibft2clienttypes.ConsensusState, error) {
+	bz, found, err := chain.IBCHandler.GetConsensusState(chain.CallOpts(ctx, RelayerKeyIndex), clientID, ibchandler.HeightData(height))
+	if err != nil {
+		return nil, err
+	} else if !found {
+		return nil, fmt.Errorf("consensusState not found: clientID=%v", clientID)
+	}
+	var cs ibft2clienttypes.ConsensusState
+	if err := UnmarshalWithAny(bz, &cs); err != nil {
+		return nil, err
+	}
+	return &cs, nil
+}
+
+func (chain *Chain) GetMockClientState(ctx context.Context, clientID string) (*mockclienttypes.ClientState, error) {
+	bz, found, err := chain.IBCHandler.GetClientState(chain.CallOpts(ctx, RelayerKeyIndex), clientID)
+	if err != nil {
+		return nil, err
+	} else if !found {
+		return nil, fmt.Errorf("clientState not found: clientID=%v", clientID)
+	}
+	var cs mockclienttypes.ClientState
+	if err := UnmarshalWithAny(bz, &cs); err != nil {
+		return nil, err
+	}
+	return &cs, nil
+}
+
+func (chain *Chain) GetLightClientState(ctx context.Context, counterparty *Chain, counterpartyClientID string, storageKeys [][]byte, height *big.Int) (LightClientState, error) {
+	if height == nil {
+		module, err := GetLightClientModule(chain.ClientType())
+		if err != nil {
+			return nil, err
+		}
+		latestHeight, err := module.GetLatestHeight(ctx, counterparty, counterpartyClientID)
+		if err != nil {
+			return nil, err
+		}
+		if latestHeight.RevisionNumber != chain.RevisionNumber() {
+			return nil, fmt.Errorf("the client tracks another revision: client=%v revision_number=%v chain_revision_number=%v", counterpartyClientID, latestHeight.RevisionNumber, chain.RevisionNumber())
+		}
+		height = latestHeight.ToBN()
+	}
+	return chain.lc.GetState(
+		ctx,
+		chain.ContractConfig.IBCHandlerAddress,
+		storageKeys,
+		height,
+	)
+}
+
+func (chain *Chain) ConstructMockMsgCreateClient(counterparty *Chain) (ibchandler.IBCMsgsMsgCreateClient, error) {
+	clientState := mockclienttypes.ClientState{
+		LatestHeight: counterparty.LastHeight(),
+	}
+	consensusState := mockclienttypes.ConsensusState{
+		Timestamp: counterparty.LastHeader().Time * 1e9,
+	}
+	clientStateBytes, err := MarshalWithAny(&clientState)
+	if err != nil {
+		return ibchandler.IBCMsgsMsgCreateClient{}, err
+	}
+	consensusStateBytes, err := MarshalWithAny(&consensusState)
+	if err != nil {
+		return ibchandler.IBCMsgsMsgCreateClient{}, err
+	}
+	return ibchandler.IBCMsgsMsgCreateClient{
+		ClientType:          ibcclient.MockClient,
+		ClientStateBytes:    clientStateBytes,
+		ConsensusStateBytes: consensusStateBytes,
+	}, nil
+}
+
+func (chain *Chain) ConstructIBFT2MsgCreateClient(counterparty *Chain) (ibchandler.IBCMsgsMsgCreateClient, error) {
+	clientState := ibft2clienttypes.ClientState{
+		ChainId:         counterparty.ChainIDString(),
+		IbcStoreAddress: counterparty.ContractConfig.IBCHandlerAddress.Bytes(),
+		LatestHeight:    counterparty.LastHeight(),
+	}
+	consensusState := ibft2clienttypes.ConsensusState{
+		Timestamp:  counterparty.LastHeader().Time,
+		Root:       counterparty.LastHeader().Root.Bytes(),
+		Validators: counterparty.LastLCState.(BesuState).Validators(),
+	}
+	clientStateBytes, err := MarshalWithAny(&clientState)
+	if err != nil {
+		return ibchandler.IBCMsgsMsgCreateClient{}, err
+	}
+	consensusStateBytes, err := MarshalWithAny(&consensusState)
+	if err != nil {
+		return ibchandler.IBCMsgsMsgCreateClient{}, err
+	}
+	return ibchandler.IBCMsgsMsgCreateClient{
+		ClientType:          ibcclient.BesuIBFT2Client,
+		ClientStateBytes:    clientStateBytes,
+		ConsensusStateBytes: consensusStateBytes,
+	}, nil
+}
+
+func (chain *Chain) ConstructMockMsgUpdateClient(counterparty *Chain, clientID string) (ibchandler.IBCMsgsMsgUpdateClient, error) {
+	cs := counterparty.LastLCState.(ETHState)
+	header := mockclienttypes.Header{
+		Height:    cs.Height(),
+		Timestamp: cs.Header().Time,
+	}
+	bz, err := MarshalWithAny(&header)
+	if err != nil {
+		return ibchandler.IBCMsgsMsgUpdateClient{}, err
+	}
+	return ibchandler.IBCMsgsMsgUpdateClient{
+		ClientId:      clientID,
+		ClientMessage: bz,
+	}, nil
+}
+
+func (chain *Chain) ConstructIBFT2MsgUpdateClient(ctx context.Context, counterparty *Chain, clientID string) (ibchandler.IBCMsgsMsgUpdateClient, error) {
+	clientState, err := chain.GetIBFT2ClientState(ctx, clientID)
+	if err != nil {
+		return ibchandler.IBCMsgsMsgUpdateClient{}, err
+	}
+	trustedHeight := clientState.LatestHeight
+	cs := counterparty.LastLCState.(BesuState)
+	var header = ibft2clienttypes.Header{
+		BesuHeaderRlp:     cs.SealingHeaderRLP(),
+		Seals:             cs.GetCommitSeals(),
+		TrustedHeight:     trustedHeight,
+		AccountStateProof: cs.Proof().AccountProofRLP,
+	}
+	bz, err := MarshalWithAny(&header)
+	if err != nil {
+		return ibchandler.IBCMsgsMsgUpdateClient{}, err
+	}
+	return ibchandler.IBCMsgsMsgUpdateClient{
+		ClientId:      clientID,
+		ClientMessage: bz,
+	}, nil
+}
+
+// UpdateHeader fetches the state of a header newer than the last one. It waits for a new block for up to 30 seconds.
+func (chain *Chain) UpdateHeader(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	for {
+		state, err := chain.lc.GetState(ctx, chain.ContractConfig.IBCHandlerAddress, nil, nil)
+		if err != nil {
+			return err
+		}
+		if chain.LastLCState == nil || state.Header().Number.Cmp(chain.LastHeader().Number) == 1 {
+			chain.LastLCState = state
+			return nil
+		} else {
+			continue
+		}
+	}
+}
+
+// CreateClient creates a client of `counterparty` with the LightClientModule registered for the client type.
+func (chain *Chain) CreateClient(ctx context.Context, counterparty *Chain, clientType string) (string, error) {
+	module, err := GetLightClientModule(clientType)
+	if err != nil {
+		return "", err
+	}
+	msg, err := module.ConstructMsgCreateClient(ctx, chain, counterparty)
+	if err != nil {
+		return "", err
+	}
+	if err := chain.WaitIfNoError(ctx)(
+		chain.IBCHandler.CreateClient(chain.TxOpts(ctx, RelayerKeyIndex), msg),
+	); err != nil {
+		return "", err
+	}
+	return chain.GetLastGeneratedClientID(ctx)
+}
+
+// UpdateClient updates the client of `counterparty` to the last header of `counterparty` with the
+// LightClientModule registered for the client type of `counterparty`.
+func (chain *Chain) UpdateClient(ctx context.Context, counterparty *Chain, clientID string) error {
+	module, err := GetLightClientModule(counterparty.ClientType())
+	if err != nil {
+		return err
+	}
+	msg, err := module.ConstructMsgUpdateClient(ctx, chain, counterparty, clientID)
+	if err != nil {
+		return err
+	}
+	return chain.WaitIfNoError(ctx)(
+		chain.IBCHandler.UpdateClient(chain.TxOpts(ctx, RelayerKeyIndex), msg),
+	)
+}
+
+func (chain *Chain) CreateMockClient(ctx context.Context, counterparty *Chain) (string, error) {
+	msg, err := chain.ConstructMockMsgCreateClient(counterparty)
+	if err != nil {
+		return "", err
+	}
+	if err := chain.WaitIfNoError(ctx)(
+		chain.IBCHandler.CreateClient(chain.TxOpts(ctx, RelayerKeyIndex), msg),
+	); err != nil {
+		return "", err
+	}
+	return chain.GetLastGeneratedClientID(ctx)
+}
+
+func (chain *Chain) UpdateMockClient(ctx context.Context, counterparty *Chain, clientID string) error {
+	msg, err := chain.ConstructMockMsgUpdateClient(counterparty, clientID)
+	if err != nil {
+		return err
+	}
+	return chain.WaitIfNoError(ctx)(
+		chain.IBCHandler.UpdateClient(chain.TxOpts(ctx, RelayerKeyIndex), msg),
+	)
+}
+
+func (chain *Chain) CreateIBFT2Client(ctx context.Context, counterparty *Chain) (string, error) {
+	msg, err := chain.ConstructIBFT2MsgCreateClient(counterparty)
+	if err != nil {
+		return "", err
+	}
+	if err := chain.WaitIfNoError(ctx)(
+		chain.IBCHandler.CreateClient(chain.TxOpts(ctx, RelayerKeyIndex), msg),
+	); err != nil {
+		return "", err
+	}
+	return chain.GetLastGeneratedClientID(ctx)
+}
+
+func (chain *Chain) UpdateIBFT2Client(ctx context.Context, counterparty *Chain, clientID string) error {
+	msg, err := chain.ConstructIBFT2MsgUpdateClient(ctx, counterparty, clientID)
+	if err != nil {
+		return err
+	}
+	return chain.WaitIfNoError(ctx)(
+		chain.IBCHandler.UpdateClient(chain.TxOpts(ctx, RelayerKeyIndex), msg),
+	)
+}
+
+func (chain *Chain) ConnectionOpenInit(ctx context.Context, counterparty *Chain, connection, counterpartyConnection *Connection) (string, error) {
+	if err := chain.WaitIfNoError(ctx)(
+		chain.IBCHandler.ConnectionOpenInit(
+			chain.TxOpts(ctx, RelayerKeyIndex),
+			ibchandler.IBCMsgsMsgConnectionOpenInit{
+				ClientId: connection.ClientID,
+				Counterparty: ibchandler.CounterpartyData{
+					ClientId:     connection.CounterpartyClientID,
+					ConnectionId: "",
+					Prefix:       ibchandler.MerklePrefixData{KeyPrefix: counterparty.GetCommitmentPrefix()},
+				},
+				DelayPeriod: DefaultDelayPeriod,
+			},
+		),
+	); err != nil {
+		return "", err
+	}
+	return chain.GetLastGeneratedConnectionID(ctx)
+}
+
+func (chain *Chain) ConnectionOpenTry(ctx context.Context, counterparty *Chain, connection, counterpartyConnection *Connection) (string, error) {
+	proofConnection, err := counterparty.QueryConnectionProof(ctx, chain, connection.ClientID, counterpartyConnection.ID, nil)
+	if err != nil {
+		return "", err
+	}
+	clientStateBytes, proofClient, err := counterparty.QueryClientProof(ctx, chain, counterpartyConnection.ClientID, proofConnection.Height.ToBN())
+	if err != nil {
+		return "", err
+	}
+	if err := chain.WaitIfNoError(ctx)(
+		chain.IBCHandler.ConnectionOpenTry(
+			chain.TxOpts(ctx, RelayerKeyIndex),
+			ibchandler.IBCMsgsMsgConnectionOpenTry{
+				Counterparty: ibchandler.CounterpartyData{
+					ClientId:     counterpartyConnection.ClientID,
+					ConnectionId: counterpartyConnection.ID,
+					Prefix:       ibchandler.MerklePrefixData{KeyPrefix: counterparty.GetCommitmentPrefix()},
+				},
+				DelayPeriod:      DefaultDelayPeriod,
+				ClientId:         connection.ClientID,
+				ClientStateBytes: clientStateBytes,
+				CounterpartyVersions: []ibchandler.VersionData{
+					{Identifier: "1", Features: []string{"ORDER_ORDERED", "ORDER_UNORDERED"}},
+				},
+				ProofHeight: proofConnection.Height.ToCallData(),
+				ProofInit:   proofConnection.Data,
+				ProofClient: proofClient.Data,
+			},
+		),
+	); err != nil {
+		return "", err
+	}
+	return chain.GetLastGeneratedConnectionID(ctx)
+}
+
+// ConnectionOpenAck will construct and execute a MsgConnectionOpenAck.
+func (chain *Chain) ConnectionOpenAck(
+	ctx context.Context,
+	counterparty *Chain,
+	connection, counterpartyConnection *Connection,
+) error {
+	proofConnection, err := counterparty.QueryConnectionProof(ctx, chain, connection.ClientID, counterpartyConnection.ID, nil)
+	if err != nil {
+		return err
+	}
+	clientStateBytes, proofClient, err := counterparty.QueryClientProof(ctx, chain, counterpartyConnection.ClientID, proofConnection.Height.ToBN())
+	if err != nil {
+		return err
+	}
+	return chain.WaitIfNoError(ctx)(
+		chain.IBCHandler.ConnectionOpenAck(
+			chain.TxOpts(ctx, RelayerKeyIndex),
+			ibchandler.IBCMsgsMsgConnectionOpenAck{
+				ConnectionId:             connection.ID,
+				CounterpartyConnectionID: counterpartyConnection.ID,
+				ClientStateBytes:         clientStateBytes,
+				Version:                  ibchandler.VersionData{Identifier: "1", Features: []string{"ORDER_ORDERED", "ORDER_UNORDERED"}},
+				ProofHeight:              proofConnection.Height.ToCallData(),
+				ProofTry:                 proofConnection.Data,
+				ProofClient:              proofClient.Data,
+			},
+		),
+	)
+}
+
+func (chain *Chain) ConnectionOpenConfirm(
+	ctx context.Context,
+	counterparty *Chain,
+	connection, counterpartyConnection *Connection,
+) error {
+	proof, err := counterparty.QueryConnectionProof(ctx, chain, connection.ClientID, counterpartyConnection.ID, nil)
+	if err != nil {
+		return err
+	}
+	return chain.WaitIfNoError(ctx)(
+		chain.IBCHandler.ConnectionOpenConfirm(
+			chain.TxOpts(ctx, RelayerKeyIndex),
+			ibchandler.IBCMsgsMsgConnectionOpenConfirm{
+				ConnectionId: connection.ID,
+				ProofAck:     proof.Data,
+				ProofHeight:  proof.Height.ToCallData(),
+			},
+		),
+	)
+}
+
+func (chain *Chain) ChannelOpenInit(
+	ctx context.Context,
+	ch, counterparty Channel,
+	order channeltypes.Channel_Order,
+	connectionID string,
+) (string, error) {
+	if err := chain.WaitIfNoError(ctx)(
+		chain.IBCHandler.ChannelOpenInit(
+			chain.TxOpts(ctx, RelayerKeyIndex),
+			ibchandler.IBCMsgsMsgChannelOpenInit{
+				PortId: ch.PortID,
+				Channel: ibchandler.ChannelData{
+					State:    uint8(channeltypes.INIT),
+					Ordering: uint8(order),
+					Counterparty: ibchandler.ChannelCounterpartyData{
+						PortId:    counterparty.PortID,
+						ChannelId: "",
+					},
+					ConnectionHops: []string{connectionID},
+					Version:        ch.Version,
+				},
+			},
+		),
+	); err != nil {
+		return "", err
+	}
+	return chain.GetLastGeneratedChannelID(ctx)
+}
+
+func (chain *Chain) ChannelOpenTry(
+	ctx context.Context,
+	counterparty *Chain,
+	ch, counterpartyCh Channel,
+	order channeltypes.Channel_Order,
+	connectionID string,
+) (string, error) {
+	proof, err := counterparty.QueryChannelProof(ctx, chain, ch.ClientID, counterpartyCh, nil)
+	if err != nil {
+		return "", err
+	}
+	if err := chain.WaitIfNoError(ctx)(
+		chain.IBCHandler.ChannelOpenTry(
+			chain.TxOpts(ctx, RelayerKeyIndex),
+			ibchandler.IBCMsgsMsgChannelOpenTry{
+				PortId: ch.PortID,
+				Channel: ibchandler.ChannelData{
+					State:    uint8(channeltypes.TRYOPEN),
+					Ordering: uint8(order),
+					Counterparty: ibchandler.ChannelCounterpartyData{
+						PortId:    counterpartyCh.PortID,
+						ChannelId: counterpartyCh.ID,
+					},
+					ConnectionHops: []string{connectionID},
+					Version:        ch.Version,
+				},
+				CounterpartyVersion: counterpartyCh.Version,
+				ProofInit:           proof.Data,
+				ProofHeight:         proof.Height.ToCallData(),
+			},
+		),
+	); err != nil {
+		return "", err
+	}
+	return chain.GetLastGeneratedChannelID(ctx)
+}
+
+func (chain *Chain) ChannelOpenAck(
+	ctx context.Context,
+	counterparty *Chain,
+	ch, counterpartyCh Channel,
+) error {
+	proof, err := counterparty.QueryChannelProof(ctx, chain, ch.ClientID, counterpartyCh, nil)
+	if err != nil {
+		return err
+	}
+	return chain.WaitIfNoError(ctx)(
+		chain.IBCHandler.ChannelOpenAck(
+			chain.TxOpts(ctx, RelayerKeyIndex),
+			ibchandler.IBCMsgsMsgChannelOpenAck{
+				PortId:                ch.PortID,
+				ChannelId:             ch.ID,
+				CounterpartyVersion:   counterpartyCh.Version,
+				CounterpartyChannelId: counterpartyCh.ID,
+				ProofTry:              proof.Data,
+				ProofHeight:           proof.Height.ToCallData(),
+			},
+		),
+	)
+}
+
+func (chain *Chain) ChannelOpenConfirm(
+	ctx context.Context,
+	counterparty *Chain,
+	ch, counterpartyCh Channel,
+) error {
+	proof, err := counterparty.QueryChannelProof(ctx, chain, ch.ClientID, counterpartyCh, nil)
+	if err != nil {
+		return err
+	}
+	return chain.WaitIfNoError(ctx)(
+		chain.IBCHandler.ChannelOpenConfirm(
+			chain.TxOpts(ctx, RelayerKeyIndex),
+			ibchandler.IBCMsgsMsgChannelOpenConfirm{
+				PortId:      ch.PortID,
+				ChannelId:   ch.ID,
+				ProofAck:    proof.Data,
+				ProofHeight: proof.Height.ToCallData(),
+			},
+		),
+	)
+}
+
+func (chain *Chain) ChannelCloseInit(
+	ctx context.Context,
+	ch Channel,
+) error {
+	return chain.WaitIfNoError(ctx)(
+		chain.IBCHandler.ChannelCloseInit(
+			chain.TxOpts(ctx, RelayerKeyIndex),
+			ibchandler.IBCMsgsMsgChannelCloseInit{
+				PortId:    ch.PortID,
+				ChannelId: ch.ID,
+			},
+		),
+	)
+}
+
+func (chain *Chain) ChannelCloseConfirm(
+	ctx context.Context,
+	counterparty *Chain,
+	ch, counterpartyCh Channel,
+) error {
+	proof, err := counterparty.QueryChannelProof(ctx, chain, ch.ClientID, counterpartyCh, nil)
+	if err != nil {
+		return err
+	}
+	return chain.WaitIfNoError(ctx)(
+		chain.IBCHandler.ChannelCloseConfirm(
+			chain.TxOpts(ctx, RelayerKeyIndex),
+			ibchandler.IBCMsgsMsgChannelCloseConfirm{
+				PortId:      ch.PortID,
+				ChannelId:   ch.ID,
+				ProofInit:   proof.Data,
+				ProofHeight: proof.Height.ToCallData(),
+			},
+		),
+	)
+}
+
+func (chain *Chain) SendPacket(
+	ctx context.Context,
+	packet channeltypes.Packet,
+) error {
+	return chain.WaitIfNoError(ctx)(
+		chain.IBCHandler.SendPacket(
+			chain.TxOpts(ctx, RelayerKeyIndex),
+			packet.SourcePort,
+			packet.SourceChannel,
+			packet.TimeoutHeight.ToCallData(),
+			packet.TimeoutTimestamp,
+			packet.Data,
+		),
+	)
+}
+
+func (chain *Chain) HandlePacketRecv(
+	ctx context.Context,
+	counterparty *Chain,
+	ch, counterpartyCh Channel,
+	packet channeltypes.Packet,
+) error {
+	proof, err := counterparty.QueryMembershipProof(ctx, chain, ch.ClientID, counterparty.commitmentLayout.PacketCommitmentSlot(packet.SourcePort, packet.SourceChannel, packet.Sequence), commitPacket(packet), nil)
+	if err != nil {
+		return err
+	}
+	return chain.WaitIfNoError(ctx)(
+		chain.IBCHandler.RecvPacket(
+			chain.TxOpts(ctx, RelayerKeyIndex),
+			ibchandler.IBCMsgsMsgPacketRecv{
+				Packet:      conv.PacketFromPB(packet),
+				Proof:       proof.Data,
+				ProofHeight: proof.Height.ToCallData(),
+			},
+		),
+	)
+}
+
+func (chain *Chain) HandlePacketAcknowledgement(
+	ctx context.Context,
+	counterparty *Chain,
+	ch, counterpartyCh Channel,
+	packet channeltypes.Packet,
+	acknowledgement []byte,
+) error {
+	proof, err := counterparty.QueryMembershipProof(ctx, chain, ch.ClientID, counterparty.commitmentLayout.PacketAcknowledgementCommitmentSlot(packet.DestinationPort, packet.DestinationChannel, packet.Sequence), commitAcknowledgement(acknowledgement), nil)
+	if err != nil {
+		return err
+	}
+	return chain.WaitIfNoError(ctx)(
+		chain.IBCHandler.AcknowledgePacket(
+			chain.TxOpts(ctx, RelayerKeyIndex),
+			ibchandler.IBCMsgsMsgPacketAcknowledgement{
+				Packet:          conv.PacketFromPB(packet),
+				Acknowledgement: acknowledgement,
+				Proof:           proof.Data,
+				ProofHeight:     proof.Height.ToCallData(),
+			},
+		),
+	)
+}
+
+// HandlePacketTimeout times out the packet sent on `ch`, which has not been received on `counterpartyCh`. The
+// absence of the packet receipt of an UNORDERED channel, or the next receive sequence of an ORDERED channel, is
+// proven at the latest height of the client, whose consensus state must have reached the timeout of the packet.
+// An ORDERED channel is closed by the timeout.
+func (chain *Chain) HandlePacketTimeout(
+	ctx context.Context,
+	counterparty *Chain,
+	ch, counterpartyCh Channel,
+	packet channeltypes.Packet,
+) error {
+	nextSequenceRecv, proof, err := counterparty.QueryUnreceivedPacketProof(ctx, chain, ch, counterpartyCh, packet, nil)
+	if err != nil {
+		return err
+	}
+	return chain.WaitIfNoError(ctx)(
+		chain.IBCHandler.TimeoutPacket(
+			chain.TxOpts(ctx, RelayerKeyIndex),
+			ibchandler.IBCMsgsMsgTimeoutPacket{
+				Packet:           conv.PacketFromPB(packet),
+				Proof:            proof.Data,
+				ProofHeight:      proof.Height.ToCallData(),
+				NextSequenceRecv: nextSequenceRecv,
+			},
+		),
+	)
+}
+
+// HandlePacketTimeoutOnClose times out the packet sent on `ch` because `counterpartyCh` has been closed before it
+// receives the packet. The closed channel and the absence of the packet are proven at the same height, which is
+// the latest height of the client. The packet does not need to have reached its timeout.
+func (chain *Chain) HandlePacketTimeoutOnClose(
+	ctx context.Context,
+	counterparty *Chain,
+	ch, counterpartyCh Channel,
+	packet channeltypes.Packet,
+) error {
+	proofClose, err := counterparty.QueryChannelProof(ctx, chain, ch.ClientID, counterpartyCh, nil)
+	if err != nil {
+		return err
+	}
+	nextSequenceRecv, proof, err := counterparty.QueryUnreceivedPacketProof(ctx, chain, ch, counterpartyCh, packet, proofClose.Height.ToBN())
+	if err != nil {
+		return err
+	}
+	return chain.WaitIfNoError(ctx)(
+		chain.IBCHandler.TimeoutOnClose(
+			chain.TxOpts(ctx, RelayerKeyIndex),
+			ibchandler.IBCMsgsMsgTimeoutOnClose{
+				Packet:           conv.PacketFromPB(packet),
+				Proof:            proof.Data,
+				ProofClose:       proofClose.Data,
+				ProofHeight:      proof.Height.ToCallData(),
+				NextSequenceRecv: nextSequenceRecv,
+			},
+		),
+	)
+}
+
+func (chain *Chain) GetLastGeneratedClientID(
+	ctx context.Context,
+) (string, error) {
+	return chain.getLastID(ctx, index.ClientIdentifier)
+}
+
+func (chain *Chain) GetLastGeneratedConnectionID(
+	ctx context.Context,
+) (string, error) {
+	return chain.getLastID(ctx, index.ConnectionIdentifier)
+}
+
+func (chain *Chain) GetLastGeneratedChannelID(
+	ctx context.Context,
+) (string, error) {
+	return chain.getLastID(ctx, index.ChannelIdentifier)
+}
+
+func (chain *Chain) getLastID(ctx context.Context, kind index.IdentifierKind) (string, error) {
+	if err := chain.EventIndex.Sync(ctx, chain.client); err != nil {
+		return "", err
+	}
+	id, ok := chain.EventIndex.LastGeneratedIdentifier(kind)
+	if !ok {
+		return "", errors.New("no items")
+	}
+	return id.ID, nil
+}
+
+func (chain *Chain) GetLastSentPacket(
+	ctx context.Context,
+	sourcePortID string,
+	sourceChannel string,
+) (*channeltypes.Packet, error) {
+	seq, err := chain.IBCHandler.GetNextSequenceSend(chain.CallOpts(ctx, RelayerKeyIndex), sourcePortID, sourceChannel)
+	if err != nil {
+		return nil, err
+	}
+	return chain.FindPacket(ctx, sourcePortID, sourceChannel, seq-1)
+}
+
+func (chain *Chain) FindPacket(
+	ctx context.Context,
+	sourcePortID string,
+	sourceChannel string,
+	sequence uint64,
+) (*channeltypes.Packet, error) {
+	channel, found, err := chain.IBCHandler.GetChannel(chain.CallOpts(ctx, RelayerKeyIndex), sourcePortID, sourceChannel)
+	if err != nil {
+		return nil, err
+	} else if !found {
+		return nil, fmt.Errorf("channel not found: sourcePortID=%v sourceChannel=%v", sourcePortID, sourceChannel)
+	}
+
+	if err := chain.EventIndex.Sync(ctx, chain.client); err != nil {
+		return nil, err
+	}
+	p, ok := chain.EventIndex.FindPacket(sourcePortID, sourceChannel, sequence)
+	if !ok {
+		return nil, fmt.Errorf("packet not found: sourcePortID=%v sourceChannel=%v sequence=%v", sourcePortID, sourceChannel, sequence)
+	}
+	return &channeltypes.Packet{
+		Sequence:           p.Sequence,
+		SourcePort:         p.SourcePort,
+		SourceChannel:      p.SourceChannel,
+		DestinationPort:    channel.Counterparty.PortId,
+		DestinationChannel: channel.Counterparty.ChannelId,
+		Data:               p.Data,
+		TimeoutHeight:      ibcclient.NewHeight(p.TimeoutHeight.RevisionNumber, p.TimeoutHeight.RevisionHeight),
+		TimeoutTimestamp:   p.TimeoutTimestamp,
+	}, nil
+}
+
+// Querier
+
+type Proof struct {
+	Height ibcclient.Height
+	Data   []byte
+}
+
+func (chain *Chain) QueryProof(ctx context.Context, counterparty *Chain, counterpartyClientID string, storageKey string, height *big.Int) (*Proof, error) {
+	s, err := chain.getStorageKeyState(ctx, counterparty, counterpartyClientID, storageKey, height)
+	if err != nil {
+		return nil, err
+	}
+	return newProof(s), nil
+}
+
+// QueryMembershipProof returns a proof that the commitment of `value` exists at the storage key.
+// It fails if the value stored at the storage key is not keccak256(value).
+func (chain *Chain) QueryMembershipProof(ctx context.Context, counterparty *Chain, counterpartyClientID string, storageKey string, value []byte, height *big.Int) (*Proof, error) {
+	s, err := chain.getStorageKeyState(ctx, counterparty, counterpartyClientID, storageKey, height)
+	if err != nil {
+		return nil, err
+	}
+	return chain.membershipProof(ctx, s, storageKey, value)
+}
+
+func (chain *Chain) membershipProof(ctx context.Context, s LightClientState, storageKey string, value []byte) (*Proof, error) {
+	stored, err := chain.getProvenValue(ctx, s, storageKey)
+	if err != nil {
+		return nil, err
+	} else if stored == (common.Hash{}) {
+		return nil, fmt.Errorf("commitment not found: storageKey=%v height=%v", storageKey, s.Header().Number)
+	} else if expected := gethcrypto.Keccak256Hash(value); stored != expected {
+		return nil, fmt.Errorf("commitment mismatch: storageKey=%v height=%v expected=%v actual=%v", storageKey, s.Header().Number, expected, stored)
+	}
+	proof := newProof(s)
+	if err := chain.processProof(proof, func() ([]byte, error) {
+		return value, nil
+	}); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// QueryNonMembershipProof returns a proof that nothing is stored at the storage key.
+// It fails if the value stored at the storage key is not zero.
+func (chain *Chain) QueryNonMembershipProof(ctx context.Context, counterparty *Chain, counterpartyClientID string, storageKey string, height *big.Int) (*Proof, error) {
+	s, err := chain.getStorageKeyState(ctx, counterparty, counterpartyClientID, storageKey, height)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := chain.getProvenValue(ctx, s, storageKey)
+	if err != nil {
+		return nil, err
+	} else if stored != (common.Hash{}) {
+		return nil, fmt.Errorf("value exists: storageKey=%v height=%v value=%v", storageKey, s.Header().Number, stored)
+	}
+	proof := newProof(s)
+	module, err := GetLightClientModule(chain.ClientType())
+	if err != nil {
+		return nil, err
+	}
+	if err := module.ProcessNonMembershipProof(proof); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// QueryPathNonMembershipProof returns a proof that nothing is committed at the ICS-24 path, whose storage key is
+// calculated with the commitment layout of the chain.
+func (chain *Chain) QueryPathNonMembershipProof(ctx context.Context, counterparty *Chain, counterpartyClientID string, path []byte, height *big.Int) (*Proof, error) {
+	return chain.QueryNonMembershipProof(ctx, counterparty, counterpartyClientID, chain.commitmentLayout.CalculateCommitmentSlot(path), height)
+}
+
+// QueryStorageProof returns the proof of the commitment at the ICS-24 path in the self-describing format of
+// the ethereum commitment package, and the header whose state root the proof is verified against. Unlike QueryProof,
+// the proof does not depend on the light client of the counterparty, so a client on another ecosystem can verify it.
+func (chain *Chain) QueryStorageProof(ctx context.Context, path []byte, height *big.Int) (*ethcommitment.StorageProof, *gethtypes.Header, error) {
+	header, err := chain.client.HeaderByNumber(ctx, height)
+	if err != nil {
+		return nil, nil, err
+	}
+	address := chain.ContractConfig.IBCHandlerAddress
+	commitmentSlot := chain.commitmentLayout.CommitmentSlot
+	storageKeys := [][]byte{[]byte(commitment.CalculateCommitmentSlotAt(commitmentSlot, path).Hex())}
+	proof, err := chain.client.GetProof(address, storageKeys, header.Number)
+	if err != nil {
+		return nil, nil, err
+	}
+	// check the proof before it is submitted to the counterparty
+	if err := proof.Verify(header.Root, address, storageKeys); err != nil {
+		return nil, nil, err
+	}
+	storageProof, err := ethcommitment.NewStorageProof(address, commitmentSlot, path, proof, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	return storageProof, header, nil
+}
+
+func (chain *Chain) getStorageKeyState(ctx context.Context, counterparty *Chain, counterpartyClientID string, storageKey string, height *big.Int) (LightClientState, error) {
+	if !strings.HasPrefix(storageKey, "0x") {
+		return nil, fmt.Errorf("storageKey must be hex string")
+	}
+	return chain.GetLightClientState(ctx, counterparty, counterpartyClientID, [][]byte{[]byte(storageKey)}, height)
+}
+
+// getProvenValue returns the value at the storage key in the state. If the light client does not fetch the state proof,
+// the value is read from the chain instead.
+func (chain *Chain) getProvenValue(ctx context.Context, s LightClientState, storageKey string) (common.Hash, error) {
+	slot := common.HexToHash(storageKey)
+	if proof := s.Proof(); len(proof.StorageProofRLP[0]) > 0 {
+		value, _, err := proof.VerifyStorageProof(0, slot)
+		return value, err
+	}
+	bz, err := chain.client.StorageAt(ctx, chain.ContractConfig.IBCHandlerAddress, slot, s.Header().Number)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(bz), nil
+}
+
+func newProof(s LightClientState) *Proof {
+	return &Proof{
+		Height: s.Height(),
+		Data:   s.Proof().StorageProofRLP[0],
+	}
+}
+
+func (counterparty *Chain) QueryClientProof(ctx context.Context, chain *Chain, counterpartyClientID string, height *big.Int) ([]byte, *Proof, error) {
+	cs, found, err := counterparty.IBCHandler.GetClientState(counterparty.CallOpts(ctx, RelayerKeyIndex), counterpartyClientID)
+	if err != nil {
+		return nil, nil, err
+	} else if !found {
+		return nil, nil, fmt.Errorf("client not found: %v", counterpartyClientID)
+	}
+	proof, err := counterparty.QueryProof(ctx, chain, counterpartyClientID, counterparty.commitmentLayout.ClientStateCommitmentSlot(counterpartyClientID), height)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := counterparty.processProof(proof, func() ([]byte, error) {
+		return cs, nil
+	}); err != nil {
+		return nil, nil, err
+	}
+	return cs, proof, nil
+}
+
+func (counterparty *Chain) QueryConnectionProof(ctx context.Context, chain *Chain, counterpartyClientID string, counterpartyConnectionID string, height *big.Int) (*Proof, error) {
+	proof, err := counterparty.QueryProof(ctx, chain, counterpartyClientID, counterparty.commitmentLayout.ConnectionStateCommitmentSlot(counterpartyConnectionID), height)
+	if err != nil {
+		return nil, err
+	}
+	if err := counterparty.processProof(proof, func() ([]byte, error) {
+		conn, err := counterparty.QueryConnection(ctx, counterpartyConnectionID)
+		if err != nil {
+			return nil, err
+		}
+		return proto.Marshal(conn)
+	}); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+func (counterparty *Chain) QueryChannelProof(ctx context.Context, chain *Chain, counterpartyClientID string, channel Channel, height *big.Int) (*Proof, error) {
+	proof, err := counterparty.QueryProof(ctx, chain, counterpartyClientID, counterparty.commitmentLayout.ChannelStateCommitmentSlot(channel.PortID, channel.ID), height)
+	if err != nil {
+		return nil, err
+	}
+	if err := counterparty.processProof(proof, func() ([]byte, error) {
+		ch, err := counterparty.QueryChannel(ctx, channel.PortID, channel.ID)
+		if err != nil {
+			return nil, err
+		}
+		return proto.Marshal(ch)
+	}); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// QueryConnection returns the connection in the protobuf type that the IBCHandler commits to.
+func (chain *Chain) QueryConnection(ctx context.Context, connectionID string) (*connectiontypes.ConnectionEnd, error) {
+	conn, found, err := chain.IBCHandler.GetConnection(chain.CallOpts(ctx, RelayerKeyIndex), connectionID)
+	if err != nil {
+		return nil, err
+	} else if !found {
+		return nil, fmt.Errorf("connection not found: %v", connectionID)
+	}
+	return conv.ConnectionEndToPB(conn), nil
+}
+
+// QueryChannel returns the channel in the protobuf type that the IBCHandler commits to.
+func (chain *Chain) QueryChannel(ctx context.Context, portID, channelID string) (*channeltypes.Channel, error) {
+	ch, found, err := chain.IBCHandler.GetChannel(chain.CallOpts(ctx, RelayerKeyIndex), portID, channelID)
+	if err != nil {
+		return nil, err
+	} else if !found {
+		return nil, fmt.Errorf("channel not found: portID=%v channelID=%v", portID, channelID)
+	}
+	return conv.ChannelToPB(ch), nil
+}
+
+// QueryNextSequenceRecvProof returns the next receive sequence of the channel and the proof of its commitment. The
+// sequence is read at the height of the proof, so it can be submitted with the proof even if packets are received after.
+func (counterparty *Chain) QueryNextSequenceRecvProof(ctx context.Context, chain *Chain, counterpartyClientID string, channel Channel, height *big.Int) (uint64, *Proof, error) {
+	storageKey := counterparty.commitmentLayout.NextSequenceRecvCommitmentSlot(channel.PortID, channel.ID)
+	s, err := counterparty.getStorageKeyState(ctx, chain, counterpartyClientID, storageKey, height)
+	if err != nil {
+		return 0, nil, err
+	}
+	opts := counterparty.CallOpts(ctx, RelayerKeyIndex)
+	opts.BlockNumber = s.Header().Number
+	seq, err := counterparty.IBCHandler.GetNextSequenceRecv(opts, channel.PortID, channel.ID)
+	if err != nil {
+		return 0, nil, err
+	}
+	proof, err := counterparty.membershipProof(ctx, s, storageKey, commitNextSequenceRecv(seq))
+	if err != nil {
+		return 0, nil, err
+	}
+	return seq, proof, nil
+}
+
+// QueryUnreceivedPacketProof returns the proof that `channel` has not received the packet sent from `chain`. It is
+// the non-membership proof of the packet receipt if the channel is UNORDERED, or the next receive sequence and the
+// proof of its commitment if the channel is ORDERED. The next receive sequence is zero for UNORDERED channels.
+func (counterparty *Chain) QueryUnreceivedPacketProof(ctx context.Context, chain *Chain, sourceChannel, channel Channel, packet channeltypes.Packet, height *big.Int) (uint64, *Proof, error) {
+	ch, err := counterparty.QueryChannel(ctx, channel.PortID, channel.ID)
+	if err != nil {
+		return 0, nil, err
+	}
+	switch ch.Ordering {
+	case channeltypes.UNORDERED:
+		proof, err := counterparty.QueryNonMembershipProof(ctx, chain, sourceChannel.ClientID, counterparty.commitmentLayout.PacketReceiptCommitmentSlot(packet.DestinationPort, packet.DestinationChannel, packet.Sequence), height)
+		if err != nil {
+			return 0, nil, err
+		}
+		return 0, proof, nil
+	case channeltypes.ORDERED:
+		return counterparty.QueryNextSequenceRecvProof(ctx, chain, sourceChannel.ClientID, channel, height)
+	default:
+		return 0, nil, fmt.Errorf("unknown channel ordering: portID=%v channelID=%v ordering=%v", channel.PortID, channel.ID, ch.Ordering)
+	}
+}
+
+// QueryNextSequenceRecv returns the sequence of the next packet that the channel receives. It is only meaningful
+// for ORDERED channels.
+func (chain *Chain) QueryNextSequenceRecv(ctx context.Context, portID, channelID string) (uint64, error) {
+	return chain.IBCHandler.GetNextSequenceRecv(chain.CallOpts(ctx, RelayerKeyIndex), portID, channelID)
+}
+
+// QueryNextSequenceAck returns the sequence of the next packet whose acknowledgement the channel processes. It is
+// only meaningful for ORDERED channels.
+func (chain *Chain) QueryNextSequenceAck(ctx context.Context, portID, channelID string) (uint64, error) {
+	return chain.IBCHandler.GetNextSequenceAck(chain.CallOpts(ctx, RelayerKeyIndex), portID, channelID)
+}
+
+// processProof converts the proof of a commitment on the chain with the LightClientModule of the chain's client type.
+func (chain *Chain) processProof(proof *Proof, value func() ([]byte, error)) error {
+	module, err := GetLightClientModule(chain.ClientType())
+	if err != nil {
+		return err
+	}
+	return module.ProcessProof(proof, value)
+}
+
+func (chain *Chain) LastHeader() *gethtypes.Header {
+	return chain.LastLCState.Header()
+}
+
+// LastHeight returns the height of the last header with the revision number of the chain.
+func (chain *Chain) LastHeight() ibcclient.Height {
+	return chain.LastLCState.Height()
+}
+
+func (chain *Chain) WaitForReceiptAndGet(ctx context.Context, tx *gethtypes.Transaction) error {
+	from, fromErr := txSender(tx)
+	// the transaction is replaced with bumped fees if the client enables it
+	rc, _, err := chain.Client().WaitForReceiptAndReplace(ctx, tx, chain.signTx)
+	if err != nil {
+		// the transaction may be either reverted or dropped, so the pending ones are resolved by the next sync
+		if fromErr == nil {
+			chain.nonces.Invalidate(from)
+		}
+		return err
+	}
+	if fromErr == nil {
+		chain.nonces.Done(from, tx.Nonce())
+	}
+	if rc.Status == 1 {
+		return nil
+	} else {
+		return fmt.Errorf("failed to call transaction: err='%v' rc='%v'", err, rc)
+	}
+}
+
+func (chain *Chain) WaitIfNoError(ctx context.Context) func(tx *gethtypes.Transaction, err error) error {
+	return func(tx *gethtypes.Transaction, err error) error {
+		if err != nil {
+			// the nonce assigned by TxOpts is not used, so every account is synced again before its next transaction
+			chain.nonces.Reset()
+			return err
+		}
+		chain.trackTx(tx)
+		if err := chain.WaitForReceiptAndGet(ctx, tx); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+// TxFunc sends a transaction with the options, e.g. a method of a contract binding.
+type TxFunc func(opts *bind.TransactOpts) (*gethtypes.Transaction, error)
+
+// SubmitBatch sends the transactions of the key with consecutive nonces without waiting for each receipt, and then
+// waits for all the receipts together. The transactions after the one that failed to be sent are not sent, but the
+// sent ones are still waited for. A transaction that failed with "nonce too low" is retried once after a resync.
+// Unless the client has a fixed gas limit, the gas of each transaction is estimated before the preceding ones are
+// mined, so the transactions should not depend on each other on a chain that does not mine them immediately.
+func (chain *Chain) SubmitBatch(ctx context.Context, index uint32, txs ...TxFunc) error {
+	var (
+		sent    []*gethtypes.Transaction
+		sendErr error
+	)
+	for _, f := range txs {
+		tx, err := chain.sendTx(ctx, index, f)
+		if err != nil {
+			sendErr = err
+			break
+		}
+		sent = append(sent, tx)
+	}
+
+	errs := make([]error, len(sent)+1)
+	errs[0] = sendErr
+	var wg sync.WaitGroup
+	for i, tx := range sent {
+		wg.Add(1)
+		go func(i int, tx *gethtypes.Transaction) {
+			defer wg.Done()
+			if err := chain.WaitForReceiptAndGet(ctx, tx); err != nil {
+				errs[i+1] = fmt.Errorf("transaction %v: %w", tx.Hash(), err)
+			}
+		}(i, tx)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (chain *Chain) sendTx(ctx context.Context, index uint32, f TxFunc) (*gethtypes.Transaction, error) {
+	for retried := false; ; retried = true {
+		opts := chain.TxOpts(ctx, index)
+		tx, err := f(opts)
+		if err == nil {
+			chain.trackTx(tx)
+			return tx, nil
+		}
+		if opts.Nonce != nil {
+			chain.nonces.Release(opts.From, opts.Nonce.Uint64())
+		}
+		if retried || !client.IsNonceTooLow(err) {
+			return nil, err
+		}
+		if err := chain.nonces.Resync(ctx, opts.From); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (chain *Chain) trackTx(tx *gethtypes.Transaction) {
+	if from, err := txSender(tx); err == nil {
+		chain.nonces.Sent(from, tx.Nonce(), tx.Hash())
+	}
+}
+
+func txSender(tx *gethtypes.Transaction) (common.Address, error) {
+	return gethtypes.Sender(gethtypes.LatestSignerForChainID(tx.ChainId()), tx)
+}
+
+// AddConnection appends a new Connection which contains references
+// to the connection id, client id and counterparty client id.
+func (chain *Chain) AddConnection(clientID, counterpartyClientID string) *Connection {
+	conn := chain.NextConnection(clientID, counterpartyClientID)
+
+	chain.Connections = append(chain.Connections, conn)
+	return conn
+}
+
+// NextConnection constructs the next connection to be
+// created given a clientID and counterparty clientID.
+func (chain *Chain) NextConnection(clientID, counterpartyClientID string) *Connection {
+	return &Connection{
+		ID:                   "",
+		ClientID:             clientID,
+		NextChannelVersion:   DefaultChannelVersion,
+		CounterpartyClientID: counterpartyClientID,
+	}
+}
+
+// AddChannel appends a new Channel which contains references to the port and channel ID
+// used for channel creation and interaction.
+func (chain *Chain) AddChannel(conn *Connection, portID string) Channel {
+	channel := chain.NextChannel(conn, portID)
+	conn.Channels = append(conn.Channels, channel)
+	return channel
+}
+
+// NextChannel returns the next channel to be created on this connection, but does not
+// add it to the list of created channels. This function is expected to be used when the caller
+// has not created the associated channel in app state, but would still like to refer to the
+// non-existent channel usually to test for its non-existence.
+//
+// The port is passed in by the caller.
+func (chain *Chain) NextChannel(conn *Connection, portID string) Channel {
+	return Channel{
+		PortID:               portID,
+		ID:                   "",
+		ClientID:             conn.ClientID,
+		CounterpartyClientID: conn.CounterpartyClientID,
+		Version:              conn.NextChannelVersion,
+	}
+}
@@ -0,0 +1,245 @@
+package relay
+
+import (
+	"context"
+	"math/big"
+
+	"0fatih/yui-ibc-solidity/pkg/chains"
+	"0fatih/yui-ibc-solidity/pkg/client"
+	ibcclient "0fatih/yui-ibc-solidity/pkg/ibc/core/client"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+type LightClient struct {
+	client     *client.ETHClient
+	clientType string
+	// revisionNumber is the revision number of the heights of the fetched states
+	revisionNumber uint64
+}
+
+func NewLightClient(cl *client.ETHClient, clientType string) *LightClient {
+	return &LightClient{client: cl, clientType: clientType}
+}
+
+type LightClientState interface {
+	Header() *gethtypes.Header
+	Proof() *client.StateProof
+	// Height returns the height of the header, which has the revision number of the chain
+	Height() ibcclient.Height
+}
+
+func (lc LightClient) ClientType() string {
+	return lc.clientType
+}
+
+func (lc LightClient) RevisionNumber() uint64 {
+	return lc.revisionNumber
+}
+
+// SetRevisionNumber sets the revision number of the chain, which is usually parsed from the chain ID.
+func (lc *LightClient) SetRevisionNumber(revisionNumber uint64) {
+	lc.revisionNumber = revisionNumber
+}
+
+// GetState fetches the state of the chain with the LightClientModule registered for the client type.
+func (lc LightClient) GetState(ctx context.Context, address common.Address, storageKeys [][]byte, bn *big.Int) (LightClientState, error) {
+	module, err := GetLightClientModule(lc.clientType)
+	if err != nil {
+		return nil, err
+	}
+	return module.GetState(ctx, lc.client, lc.revisionNumber, address, storageKeys, bn)
+}
+
+func (lc LightClient) GetMockContractState(ctx context.Context, address common.Address, storageKeys [][]byte, bn *big.Int) (LightClientState, error) {
+	return mockLightClientModule{}.GetState(ctx, lc.client, lc.revisionNumber, address, storageKeys, bn)
+}
+
+func (lc LightClient) GetIBFT2State(ctx context.Context, address common.Address, storageKeys [][]byte, bn *big.Int) (LightClientState, error) {
+	return getIBFT2State(ctx, lc.client, lc.revisionNumber, address, storageKeys, bn)
+}
+
+func (lc LightClient) GetQBFTState(ctx context.Context, address common.Address, storageKeys [][]byte, bn *big.Int) (LightClientState, error) {
+	return getQBFTState(ctx, lc.client, lc.revisionNumber, address, storageKeys, bn)
+}
+
+func getIBFT2State(ctx context.Context, cl *client.ETHClient, revisionNumber uint64, address common.Address, storageKeys [][]byte, bn *big.Int) (LightClientState, error) {
+	state := IBFT2State{RevisionNumber: revisionNumber}
+	block, err := cl.BlockByNumber(ctx, bn)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := cl.GetProof(address, storageKeys, block.Number())
+	if err != nil {
+		return nil, err
+	}
+	// check the proof before it is submitted to the counterparty
+	if err := proof.Verify(block.Root(), address, storageKeys); err != nil {
+		return nil, err
+	}
+	state.StateProof = proof
+	state.ParsedHeader, err = chains.ParseHeader(block.Header())
+	if err != nil {
+		return nil, err
+	}
+	state.CommitSeals, err = state.ParsedHeader.ValidateAndGetCommitSeals()
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func getQBFTState(ctx context.Context, cl *client.ETHClient, revisionNumber uint64, address common.Address, storageKeys [][]byte, bn *big.Int) (LightClientState, error) {
+	state := QBFTState{RevisionNumber: revisionNumber}
+	block, err := cl.BlockByNumber(ctx, bn)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := cl.GetProof(address, storageKeys, block.Number())
+	if err != nil {
+		return nil, err
+	}
+	// check the proof before it is submitted to the counterparty
+	if err := proof.Verify(block.Root(), address, storageKeys); err != nil {
+		return nil, err
+	}
+	state.StateProof = proof
+	state.ParsedHeader, err = chains.ParseQBFTHeader(block.Header())
+	if err != nil {
+		return nil, err
+	}
+	state.CommitSeals, err = state.ParsedHeader.ValidateAndGetCommitSeals()
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+type ETHState struct {
+	header         *gethtypes.Header
+	StateProof     *client.StateProof
+	RevisionNumber uint64
+}
+
+var _ LightClientState = (*ETHState)(nil)
+
+func (cs ETHState) Header() *gethtypes.Header {
+	return cs.header
+}
+
+func (cs ETHState) Proof() *client.StateProof {
+	return cs.StateProof
+}
+
+func (cs ETHState) Height() ibcclient.Height {
+	return ibcclient.NewHeightFromRevisionAndBN(cs.RevisionNumber, cs.header.Number)
+}
+
+// BesuState is the state of a Besu chain whose headers are sealed by BFT validators.
+type BesuState interface {
+	LightClientState
+	ChainHeaderRLP() []byte
+	SealingHeaderRLP() []byte
+	GetCommitSeals() [][]byte
+	Validators() [][]byte
+}
+
+var (
+	_ BesuState = (*IBFT2State)(nil)
+	_ BesuState = (*QBFTState)(nil)
+)
+
+type IBFT2State struct {
+	ParsedHeader   *chains.ParsedHeader
+	StateProof     *client.StateProof
+	CommitSeals    [][]byte
+	RevisionNumber uint64
+}
+
+func (cs IBFT2State) Header() *gethtypes.Header {
+	return cs.ParsedHeader.Base
+}
+
+func (cs IBFT2State) Proof() *client.StateProof {
+	return cs.StateProof
+}
+
+func (cs IBFT2State) Height() ibcclient.Height {
+	return ibcclient.NewHeightFromRevisionAndBN(cs.RevisionNumber, cs.ParsedHeader.Base.Number)
+}
+
+func (cs IBFT2State) ChainHeaderRLP() []byte {
+	bz, err := cs.ParsedHeader.GetChainHeaderBytes()
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+func (cs IBFT2State) SealingHeaderRLP() []byte {
+	bz, err := cs.ParsedHeader.GetSealingHeaderBytes()
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+func (cs IBFT2State) GetCommitSeals() [][]byte {
+	return cs.CommitSeals
+}
+
+func (cs IBFT2State) Validators() [][]byte {
+	var addrs [][]byte
+	for _, val := range cs.ParsedHeader.Validators {
+		addrs = append(addrs, val.Bytes())
+	}
+	return addrs
+}
+
+type QBFTState struct {
+	ParsedHeader   *chains.ParsedQBFTHeader
+	StateProof     *client.StateProof
+	CommitSeals    [][]byte
+	RevisionNumber uint64
+}
+
+func (cs QBFTState) Header() *gethtypes.Header {
+	return cs.ParsedHeader.Base
+}
+
+func (cs QBFTState) Proof() *client.StateProof {
+	return cs.StateProof
+}
+
+func (cs QBFTState) Height() ibcclient.Height {
+	return ibcclient.NewHeightFromRevisionAndBN(cs.RevisionNumber, cs.ParsedHeader.Base.Number)
+}
+
+func (cs QBFTState) ChainHeaderRLP() []byte {
+	bz, err := cs.ParsedHeader.GetChainHeaderBytes()
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+func (cs QBFTState) SealingHeaderRLP() []byte {
+	bz, err := cs.ParsedHeader.GetSealingHeaderBytes()
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+func (cs QBFTState) GetCommitSeals() [][]byte {
+	return cs.CommitSeals
+}
+
+func (cs QBFTState) Validators() [][]byte {
+	var addrs [][]byte
+	for _, val := range cs.ParsedHeader.Validators {
+		addrs = append(addrs, val.Bytes())
+	}
+	return addrs
+}
@@ -0,0 +1,350 @@
+package relay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	host "github.com/cosmos/ibc-go/v4/modules/core/24-host"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gogo/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"0fatih/yui-ibc-solidity/pkg/client"
+	"0fatih/yui-ibc-solidity/pkg/contract/ibchandler"
+	"0fatih/yui-ibc-solidity/pkg/contract/ics20transferbank"
+	"0fatih/yui-ibc-solidity/pkg/ibc/conv"
+	"0fatih/yui-ibc-solidity/pkg/ibc/core/commitment"
+)
+
+// ContractConfig is the addresses of the contracts on a chain. Only the IBCHandler is required, and the zero address
+// means that the contract is not deployed.
+type ContractConfig struct {
+	IBCHandlerAddress              common.Address `json:"ibc_handler_address" yaml:"ibc_handler_address"`
+	ICS20TransferBankAddress       common.Address `json:"ics20_transfer_bank_address" yaml:"ics20_transfer_bank_address"`
+	ICS20BankAddress               common.Address `json:"ics20_bank_address" yaml:"ics20_bank_address"`
+	IBCCommitmentTestHelperAddress common.Address `json:"ibc_commitment_test_helper_address" yaml:"ibc_commitment_test_helper_address"`
+	ERC20TokenAddress              common.Address `json:"erc20_token_address" yaml:"erc20_token_address"`
+
+	// CommitmentSlot is the storage slot of the commitments mapping in the IBCHandler. If it is nil, the slot is
+	// detected when a Chain is constructed. Please see ResolveCommitmentLayout.
+	CommitmentSlot *common.Hash `json:"commitment_slot,omitempty" yaml:"commitment_slot,omitempty"`
+}
+
+func (cc *ContractConfig) Validate() error {
+	var zero common.Address
+	if cc.IBCHandlerAddress == zero {
+		return errors.New("IBCHandlerAddress is empty")
+	} else if cc.ICS20TransferBankAddress == zero && cc.ICS20BankAddress != zero {
+		return errors.New("ICS20BankAddress is set without ICS20TransferBankAddress")
+	} else {
+		return nil
+	}
+}
+
+// Merge overrides the addresses of the config with the non-zero addresses of other, and the commitment slot with
+// the one of other if it is set.
+func (cc *ContractConfig) Merge(other ContractConfig) {
+	for _, f := range cc.fields() {
+		if addr := *f.address(&other); addr != (common.Address{}) {
+			*f.address(cc) = addr
+		}
+	}
+	if other.CommitmentSlot != nil {
+		slot := *other.CommitmentSlot
+		cc.CommitmentSlot = &slot
+	}
+}
+
+type contractField struct {
+	// env is the suffix of the environ variable of the address
+	env     string
+	name    func(ContractNames) string
+	address func(*ContractConfig) *common.Address
+}
+
+func (cc *ContractConfig) fields() []contractField {
+	return []contractField{
+		{"IBC_HANDLER_ADDRESS", func(n ContractNames) string { return n.IBCHandler },
+			func(cc *ContractConfig) *common.Address { return &cc.IBCHandlerAddress }},
+		{"ICS20_TRANSFER_BANK_ADDRESS", func(n ContractNames) string { return n.ICS20TransferBank },
+			func(cc *ContractConfig) *common.Address { return &cc.ICS20TransferBankAddress }},
+		{"ICS20_BANK_ADDRESS", func(n ContractNames) string { return n.ICS20Bank },
+			func(cc *ContractConfig) *common.Address { return &cc.ICS20BankAddress }},
+		{"IBC_COMMITMENT_TEST_HELPER_ADDRESS", func(n ContractNames) string { return n.IBCCommitmentTestHelper },
+			func(cc *ContractConfig) *common.Address { return &cc.IBCCommitmentTestHelperAddress }},
+		{"ERC20_TOKEN_ADDRESS", func(n ContractNames) string { return n.ERC20Token },
+			func(cc *ContractConfig) *common.Address { return &cc.ERC20TokenAddress }},
+	}
+}
+
+// ContractNames are the names of the contracts in the deployment logs and artifacts.
+// An empty name means that the contract is not looked up.
+type ContractNames struct {
+	IBCHandler              string
+	ICS20TransferBank       string
+	ICS20Bank               string
+	IBCCommitmentTestHelper string
+	ERC20Token              string
+}
+
+// DefaultContractNames are the names of the contracts deployed by the deploy scripts of this repository.
+var DefaultContractNames = ContractNames{
+	IBCHandler:              "OwnableIBCHandler",
+	ICS20TransferBank:       "ICS20TransferBank",
+	ICS20Bank:               "ICS20Bank",
+	IBCCommitmentTestHelper: "IBCCommitmentTestHelper",
+	ERC20Token:              "ERC20Token",
+}
+
+// LoadContractConfigFile reads the config from a YAML file if the extension is ".yaml" or ".yml", otherwise from a JSON file.
+func LoadContractConfigFile(path string) (*ContractConfig, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cc ContractConfig
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(bz, &cc)
+	default:
+		err = json.Unmarshal(bz, &cc)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the contract config '%v': %w", path, err)
+	}
+	return &cc, nil
+}
+
+// ContractConfigFromEnv reads the addresses from the environ variables with the prefix, e.g. "{prefix}IBC_HANDLER_ADDRESS".
+// The addresses of the unset variables are zero. The commitment slot is read from "{prefix}COMMITMENT_SLOT" as a
+// decimal or a 0x-prefixed hex number.
+func ContractConfigFromEnv(getenv func(string) string, prefix string) (*ContractConfig, error) {
+	var cc ContractConfig
+	for _, f := range cc.fields() {
+		env := prefix + f.env
+		v := getenv(env)
+		if v == "" {
+			continue
+		} else if !common.IsHexAddress(v) {
+			return nil, fmt.Errorf("environ variable '%v' is not an address: %v", env, v)
+		}
+		*f.address(&cc) = common.HexToAddress(v)
+	}
+	if v := getenv(prefix + "COMMITMENT_SLOT"); v != "" {
+		slot, ok := new(big.Int).SetString(v, 0)
+		if !ok || slot.Sign() < 0 || slot.BitLen() > 256 {
+			return nil, fmt.Errorf("environ variable '%v' is not a slot: %v", prefix+"COMMITMENT_SLOT", v)
+		}
+		hash := common.BigToHash(slot)
+		cc.CommitmentSlot = &hash
+	}
+	return &cc, nil
+}
+
+type BroadcastLog struct {
+	Transactions []Transaction `json:"transactions"`
+}
+
+type Transaction struct {
+	TransactionType string         `json:"transactionType"`
+	ContractName    string         `json:"contractName"`
+	ContractAddress common.Address `json:"contractAddress"`
+}
+
+// ContractConfigFromBroadcastLog reads the addresses of the contracts created in a broadcast log of forge script,
+// e.g. "broadcast/Deploy.s.sol/{chainID}/run-latest.json".
+func ContractConfigFromBroadcastLog(path string, names ContractNames) (*ContractConfig, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var log BroadcastLog
+	if err := json.Unmarshal(bz, &log); err != nil {
+		return nil, err
+	}
+	var cc ContractConfig
+	for _, tx := range log.Transactions {
+		if tx.TransactionType != "CREATE" && tx.TransactionType != "CREATE2" {
+			continue
+		}
+		for _, f := range cc.fields() {
+			if name := f.name(names); name != "" && name == tx.ContractName {
+				*f.address(&cc) = tx.ContractAddress
+			}
+		}
+	}
+	return &cc, nil
+}
+
+// ContractConfigFromHardhatDeployments reads the addresses from the deployment files of hardhat-deploy in the directory
+// of a network, e.g. "deployments/{network}/{name}.json". The contracts without the files are not deployed.
+func ContractConfigFromHardhatDeployments(dir string, names ContractNames) (*ContractConfig, error) {
+	var cc ContractConfig
+	for _, f := range cc.fields() {
+		name := f.name(names)
+		if name == "" {
+			continue
+		}
+		var deployment struct {
+			Address common.Address `json:"address"`
+		}
+		if ok, err := readJSONFile(filepath.Join(dir, name+".json"), &deployment); err != nil {
+			return nil, err
+		} else if ok {
+			*f.address(&cc) = deployment.Address
+		}
+	}
+	return &cc, nil
+}
+
+// ContractConfigFromTruffleArtifacts reads the addresses of the network from the artifacts of truffle in the directory,
+// e.g. "build/contracts/{name}.json". The contracts without the artifacts or the networks are not deployed.
+func ContractConfigFromTruffleArtifacts(dir string, networkID string, names ContractNames) (*ContractConfig, error) {
+	var cc ContractConfig
+	for _, f := range cc.fields() {
+		name := f.name(names)
+		if name == "" {
+			continue
+		}
+		var artifact struct {
+			Networks map[string]struct {
+				Address common.Address `json:"address"`
+			} `json:"networks"`
+		}
+		if ok, err := readJSONFile(filepath.Join(dir, name+".json"), &artifact); err != nil {
+			return nil, err
+		} else if network, found := artifact.Networks[networkID]; ok && found {
+			*f.address(&cc) = network.Address
+		}
+	}
+	return &cc, nil
+}
+
+// readJSONFile returns false if the file does not exist.
+func readJSONFile(path string, v interface{}) (bool, error) {
+	bz, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(bz, v); err != nil {
+		return false, fmt.Errorf("failed to parse '%v': %w", path, err)
+	}
+	return true, nil
+}
+
+// This value is determined by the order of the state variables in ICS20Transfer.sol and ICS20TransferBank.sol
+var ics20TransferBankBankSlot = common.BigToHash(common.Big2)
+
+// DiscoverContractConfig fills the zero addresses of the ICS-20 contracts with the module bound to the transfer port
+// of the IBCHandler. The other contracts are not registered in the IBCHandler, so they cannot be discovered.
+// The handler is read with DefaultLayout; please use DiscoverContractConfigWithLayout for a forked handler.
+func DiscoverContractConfig(ctx context.Context, cl *client.ETHClient, cc *ContractConfig) error {
+	return DiscoverContractConfigWithLayout(ctx, cl, commitment.DefaultLayout, cc)
+}
+
+// DiscoverContractConfigWithLayout is DiscoverContractConfig of the IBCHandler whose storage has the layout.
+func DiscoverContractConfigWithLayout(ctx context.Context, cl *client.ETHClient, layout commitment.Layout, cc *ContractConfig) error {
+	var zero common.Address
+	if cc.IBCHandlerAddress == zero {
+		return errors.New("IBCHandlerAddress is empty")
+	}
+	if cc.ICS20TransferBankAddress == zero {
+		// the handler has no getter of the modules, so the first one bound to the port is read from the storage
+		bz, err := cl.StorageAt(ctx, cc.IBCHandlerAddress, common.HexToHash(layout.PortCapabilitySlot(TransferPort, 0)), nil)
+		if err != nil {
+			return err
+		}
+		module := common.BytesToAddress(bz)
+		if module == zero {
+			return nil
+		}
+		transfer, err := ics20transferbank.NewIcs20transferbank(module, cl)
+		if err != nil {
+			return err
+		}
+		// the module may not be an ICS20TransferBank, which has a different storage layout
+		if ibcAddress, err := transfer.IbcAddress(nil); err != nil || ibcAddress != cc.IBCHandlerAddress {
+			return nil
+		}
+		cc.ICS20TransferBankAddress = module
+	}
+	if cc.ICS20BankAddress == zero {
+		bz, err := cl.StorageAt(ctx, cc.ICS20TransferBankAddress, ics20TransferBankBankSlot, nil)
+		if err != nil {
+			return err
+		}
+		cc.ICS20BankAddress = common.BytesToAddress(bz)
+	}
+	return nil
+}
+
+// ResolveCommitmentLayout returns the commitment layout of the IBCHandler of the config. The configured slot, or
+// commitment.DefaultCommitmentSlot if it is nil, is validated with the commitment of a client state or a connection
+// that exists on the chain. If the default slot does not have the commitment, the slot is detected by probing.
+// A handler without clients and connections cannot be validated, so the slot is trusted.
+func ResolveCommitmentLayout(ctx context.Context, cl *client.ETHClient, cc ContractConfig) (commitment.Layout, error) {
+	layout := commitment.DefaultLayout
+	if cc.CommitmentSlot != nil {
+		layout = commitment.NewLayout(*cc.CommitmentSlot)
+	}
+	// the commitment and the storage are read at the same block, so they are consistent
+	header, err := cl.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return commitment.Layout{}, err
+	}
+	path, value, found, err := findKnownCommitment(ctx, cl, cc.IBCHandlerAddress, header.Number)
+	if err != nil {
+		return commitment.Layout{}, err
+	} else if !found {
+		return layout, nil
+	}
+	err = layout.VerifyCommitment(ctx, cl, cc.IBCHandlerAddress, path, value, header.Number)
+	if err == nil {
+		return layout, nil
+	} else if cc.CommitmentSlot != nil || !errors.Is(err, commitment.ErrCommitmentMismatch) {
+		return commitment.Layout{}, fmt.Errorf("invalid commitment slot: %w", err)
+	}
+	slot, err := commitment.DetectCommitmentSlot(ctx, cl, cc.IBCHandlerAddress, path, value, header.Number)
+	if err != nil {
+		return commitment.Layout{}, err
+	}
+	return commitment.NewLayout(slot), nil
+}
+
+// findKnownCommitment returns the path and the value of a commitment that exists on the chain: the client state of
+// the first client if it has a registered client type, or the first connection. The handler reverts for an unknown
+// client, so a failed query of a client state means that the client does not exist.
+func findKnownCommitment(ctx context.Context, cl *client.ETHClient, handlerAddress common.Address, blockNumber *big.Int) ([]byte, []byte, bool, error) {
+	handler, err := ibchandler.NewIbchandler(handlerAddress, cl)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: blockNumber}
+	for _, clientType := range RegisteredClientTypes() {
+		clientID := clientType + "-0"
+		if cs, found, err := handler.GetClientState(opts, clientID); err == nil && found {
+			return host.FullClientStateKey(clientID), cs, true, nil
+		}
+	}
+	const connectionID = "connection-0"
+	conn, found, err := handler.GetConnection(opts, connectionID)
+	if err != nil {
+		return nil, nil, false, err
+	} else if !found {
+		return nil, nil, false, nil
+	}
+	bz, err := proto.Marshal(conv.ConnectionEndToPB(conn))
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return host.ConnectionKey(connectionID), bz, true, nil
+}
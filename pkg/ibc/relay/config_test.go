@@ -0,0 +1,127 @@
+package relay
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testHandlerAddress  = common.HexToAddress("0x0000000000000000000000000000000000000001")
+	testTransferAddress = common.HexToAddress("0x0000000000000000000000000000000000000002")
+	testBankAddress     = common.HexToAddress("0x0000000000000000000000000000000000000003")
+)
+
+func writeFile(t *testing.T, path, content string) string {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestContractConfigValidate(t *testing.T) {
+	require.Error(t, (&ContractConfig{}).Validate())
+	// the app contracts are optional
+	require.NoError(t, (&ContractConfig{IBCHandlerAddress: testHandlerAddress}).Validate())
+	require.Error(t, (&ContractConfig{IBCHandlerAddress: testHandlerAddress, ICS20BankAddress: testBankAddress}).Validate())
+}
+
+func TestLoadContractConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	expected := &ContractConfig{IBCHandlerAddress: testHandlerAddress, ICS20TransferBankAddress: testTransferAddress}
+
+	cc, err := LoadContractConfigFile(writeFile(t, filepath.Join(dir, "contracts.yaml"), `
+ibc_handler_address: "0x0000000000000000000000000000000000000001"
+ics20_transfer_bank_address: "0x0000000000000000000000000000000000000002"
+`))
+	require.NoError(t, err)
+	require.Equal(t, expected, cc)
+
+	cc, err = LoadContractConfigFile(writeFile(t, filepath.Join(dir, "contracts.json"), `{
+  "ibc_handler_address": "0x0000000000000000000000000000000000000001",
+  "ics20_transfer_bank_address": "0x0000000000000000000000000000000000000002"
+}`))
+	require.NoError(t, err)
+	require.Equal(t, expected, cc)
+
+	_, err = LoadContractConfigFile(writeFile(t, filepath.Join(dir, "invalid.json"), `{"ibc_handler_address": "0x1"}`))
+	require.Error(t, err)
+
+	// the commitment slot is optional
+	cc, err = LoadContractConfigFile(writeFile(t, filepath.Join(dir, "slot.yaml"), `
+ibc_handler_address: "0x0000000000000000000000000000000000000001"
+commitment_slot: "0x0000000000000000000000000000000000000000000000000000000000000003"
+`))
+	require.NoError(t, err)
+	require.Equal(t, common.BigToHash(big.NewInt(3)), *cc.CommitmentSlot)
+}
+
+func TestContractConfigFromEnv(t *testing.T) {
+	env := map[string]string{
+		"TEST_IBC_HANDLER_ADDRESS": testHandlerAddress.Hex(),
+		"TEST_ICS20_BANK_ADDRESS":  testBankAddress.Hex(),
+	}
+	cc, err := ContractConfigFromEnv(func(key string) string { return env[key] }, "TEST_")
+	require.NoError(t, err)
+	require.Equal(t, &ContractConfig{IBCHandlerAddress: testHandlerAddress, ICS20BankAddress: testBankAddress}, cc)
+
+	// the non-zero addresses override the config
+	base := ContractConfig{IBCHandlerAddress: testTransferAddress, ICS20TransferBankAddress: testTransferAddress}
+	base.Merge(*cc)
+	require.Equal(t, ContractConfig{
+		IBCHandlerAddress:        testHandlerAddress,
+		ICS20TransferBankAddress: testTransferAddress,
+		ICS20BankAddress:         testBankAddress,
+	}, base)
+
+	env["TEST_COMMITMENT_SLOT"] = "0x10"
+	cc, err = ContractConfigFromEnv(func(key string) string { return env[key] }, "TEST_")
+	require.NoError(t, err)
+	require.Equal(t, common.BigToHash(big.NewInt(16)), *cc.CommitmentSlot)
+	// the commitment slot overrides the config if it is set
+	base.Merge(*cc)
+	require.Equal(t, cc.CommitmentSlot, base.CommitmentSlot)
+	base.Merge(ContractConfig{})
+	require.Equal(t, cc.CommitmentSlot, base.CommitmentSlot)
+
+	env["TEST_COMMITMENT_SLOT"] = "-1"
+	_, err = ContractConfigFromEnv(func(key string) string { return env[key] }, "TEST_")
+	require.Error(t, err)
+	env["TEST_COMMITMENT_SLOT"] = "3"
+
+	env["TEST_ERC20_TOKEN_ADDRESS"] = "token"
+	_, err = ContractConfigFromEnv(func(key string) string { return env[key] }, "TEST_")
+	require.Error(t, err)
+}
+
+func TestContractConfigFromDeployments(t *testing.T) {
+	dir := t.TempDir()
+	names := DefaultContractNames
+	names.IBCHandler = "IBCHandler"
+
+	// forge
+	cc, err := ContractConfigFromBroadcastLog(writeFile(t, filepath.Join(dir, "broadcast", "run-latest.json"), `{"transactions": [
+  {"transactionType": "CREATE", "contractName": "IBCHandler", "contractAddress": "0x0000000000000000000000000000000000000001"},
+  {"transactionType": "CREATE2", "contractName": "ICS20TransferBank", "contractAddress": "0x0000000000000000000000000000000000000002"},
+  {"transactionType": "CALL", "contractName": "ICS20Bank", "contractAddress": "0x0000000000000000000000000000000000000003"}
+]}`), names)
+	require.NoError(t, err)
+	require.Equal(t, &ContractConfig{IBCHandlerAddress: testHandlerAddress, ICS20TransferBankAddress: testTransferAddress}, cc)
+
+	// hardhat-deploy
+	writeFile(t, filepath.Join(dir, "deployments", "IBCHandler.json"), `{"address": "0x0000000000000000000000000000000000000001", "abi": []}`)
+	writeFile(t, filepath.Join(dir, "deployments", "ICS20Bank.json"), `{"address": "0x0000000000000000000000000000000000000003", "abi": []}`)
+	cc, err = ContractConfigFromHardhatDeployments(filepath.Join(dir, "deployments"), names)
+	require.NoError(t, err)
+	require.Equal(t, &ContractConfig{IBCHandlerAddress: testHandlerAddress, ICS20BankAddress: testBankAddress}, cc)
+
+	// truffle
+	writeFile(t, filepath.Join(dir, "build", "IBCHandler.json"), `{"networks": {"1337": {"address": "0x0000000000000000000000000000000000000001"}}}`)
+	writeFile(t, filepath.Join(dir, "build", "ICS20Bank.json"), `{"networks": {"5": {"address": "0x0000000000000000000000000000000000000003"}}}`)
+	cc, err = ContractConfigFromTruffleArtifacts(filepath.Join(dir, "build"), "1337", names)
+	require.NoError(t, err)
+	require.Equal(t, &ContractConfig{IBCHandlerAddress: testHandlerAddress}, cc)
+}
@@ -0,0 +1,558 @@
+package relay
+
+import (
+	"context"
+
+	channeltypes "0fatih/yui-ibc-solidity/pkg/ibc/core/channel"
+)
+
+// Coordinator executes the handshakes and relays the packets between the chains. After each message is executed on a
+// chain, the client of the chain on the counterparty is updated, so the next message can be proven.
+type Coordinator struct {
+	chains []*Chain
+}
+
+func NewCoordinator(ctx context.Context, chains ...*Chain) (Coordinator, error) {
+	for _, chain := range chains {
+		// initialize LastLCState of chain
+		if err := chain.UpdateHeader(ctx); err != nil {
+			return Coordinator{}, err
+		}
+	}
+	return Coordinator{chains: chains}, nil
+}
+
+func (c Coordinator) GetChain(idx int) *Chain {
+	return c.chains[idx]
+}
+
+// SetupClients is a helper function to create clients on both chains.
+func (coord *Coordinator) SetupClients(
+	ctx context.Context,
+	chainA, chainB *Chain,
+	clientType string,
+) (string, string, error) {
+
+	clientA, err := coord.CreateClient(ctx, chainA, chainB, clientType)
+	if err != nil {
+		return "", "", err
+	}
+
+	clientB, err := coord.CreateClient(ctx, chainB, chainA, clientType)
+	if err != nil {
+		return "", "", err
+	}
+
+	return clientA, clientB, nil
+}
+
+// SetupClientConnections is a helper function to create clients and the appropriate
+// connections on both the source and counterparty chain.
+func (coord *Coordinator) SetupClientConnections(
+	ctx context.Context,
+	chainA, chainB *Chain,
+	clientType string,
+) (string, string, *Connection, *Connection, error) {
+
+	clientA, clientB, err := coord.SetupClients(ctx, chainA, chainB, clientType)
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+
+	connA, connB, err := coord.CreateConnection(ctx, chainA, chainB, clientA, clientB)
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+
+	return clientA, clientB, connA, connB, nil
+}
+
+func (coord *Coordinator) UpdateHeaders(ctx context.Context) error {
+	for _, c := range coord.chains {
+		if err := c.UpdateHeader(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c Coordinator) CreateClient(
+	ctx context.Context,
+	source, counterparty *Chain,
+	clientType string,
+) (string, error) {
+	return source.CreateClient(ctx, counterparty, clientType)
+}
+
+func (c Coordinator) UpdateClient(
+	ctx context.Context,
+	source, counterparty *Chain,
+	clientID string,
+) error {
+	return source.UpdateClient(ctx, counterparty, clientID)
+}
+
+// CreateConnection constructs and executes connection handshake messages in order to create
+// OPEN channels on chainA and chainB. The connection information of for chainA and chainB
+// are returned within a Connection struct.
+func (c *Coordinator) CreateConnection(
+	ctx context.Context,
+	chainA, chainB *Chain,
+	clientA, clientB string,
+) (*Connection, *Connection, error) {
+
+	connA, connB, err := c.ConnOpenInit(ctx, chainA, chainB, clientA, clientB)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := c.ConnOpenTry(ctx, chainB, chainA, connB, connA); err != nil {
+		return nil, nil, err
+	}
+	if err := c.ConnOpenAck(ctx, chainA, chainB, connA, connB); err != nil {
+		return nil, nil, err
+	}
+	if err := c.ConnOpenConfirm(ctx, chainB, chainA, connB, connA); err != nil {
+		return nil, nil, err
+	}
+
+	return connA, connB, nil
+}
+
+// CreateChannel constructs and executes channel handshake messages in order to create
+// OPEN channels on chainA and chainB.
+func (c *Coordinator) CreateChannel(
+	ctx context.Context,
+	chainA, chainB *Chain,
+	connA, connB *Connection,
+	sourcePortID, counterpartyPortID string,
+	order channeltypes.Channel_Order,
+) (Channel, Channel, error) {
+
+	channelA, channelB, err := c.ChanOpenInit(ctx, chainA, chainB, connA, connB, sourcePortID, counterpartyPortID, order)
+	if err != nil {
+		return Channel{}, Channel{}, err
+	}
+
+	if err := c.ChanOpenTry(ctx, chainB, chainA, &channelB, &channelA, connB, order); err != nil {
+		return Channel{}, Channel{}, err
+	}
+
+	if err := c.ChanOpenAck(ctx, chainA, chainB, channelA, channelB); err != nil {
+		return Channel{}, Channel{}, err
+	}
+
+	if err := c.ChanOpenConfirm(ctx, chainB, chainA, channelB, channelA); err != nil {
+		return Channel{}, Channel{}, err
+	}
+
+	return channelA, channelB, nil
+}
+
+// CloseChannel constructs and executes channel closing messages in order to transition
+// the channel to the CLOSED state on chainA and chainB.
+func (c *Coordinator) CloseChannel(
+	ctx context.Context,
+	chainA, chainB *Chain,
+	chanA, chanB Channel,
+) error {
+	if err := c.ChanCloseInit(ctx, chainA, chainB, chanA); err != nil {
+		return err
+	}
+
+	return c.ChanCloseConfirm(ctx, chainB, chainA, chanB, chanA)
+}
+
+// ConnOpenInit initializes a connection on the source chain with the state INIT
+// using the OpenInit handshake call.
+//
+// NOTE: The counterparty connection will be created even if it is not created in the
+// application state.
+func (c Coordinator) ConnOpenInit(
+	ctx context.Context,
+	source, counterparty *Chain,
+	clientID, counterpartyClientID string,
+) (*Connection, *Connection, error) {
+
+	sourceConnection := source.AddConnection(clientID, counterpartyClientID)
+	counterpartyConnection := counterparty.AddConnection(counterpartyClientID, clientID)
+
+	// initialize connection on source
+	if connID, err := source.ConnectionOpenInit(ctx, counterparty, sourceConnection, counterpartyConnection); err != nil {
+		return sourceConnection, counterpartyConnection, err
+	} else {
+		sourceConnection.ID = connID
+	}
+
+	if err := source.UpdateHeader(ctx); err != nil {
+		return sourceConnection, counterpartyConnection, err
+	}
+
+	// update source client on counterparty connection
+	if err := c.UpdateClient(
+		ctx,
+		counterparty, source,
+		counterpartyClientID,
+	); err != nil {
+		return sourceConnection, counterpartyConnection, err
+	}
+
+	return sourceConnection, counterpartyConnection, nil
+}
+
+// ConnOpenTry initializes a connection on the source chain with the state TRYOPEN
+// using the OpenTry handshake call.
+func (c *Coordinator) ConnOpenTry(
+	ctx context.Context,
+	source, counterparty *Chain,
+	sourceConnection, counterpartyConnection *Connection,
+) error {
+
+	if connID, err := source.ConnectionOpenTry(ctx, counterparty, sourceConnection, counterpartyConnection); err != nil {
+		return err
+	} else {
+		sourceConnection.ID = connID
+	}
+
+	if err := source.UpdateHeader(ctx); err != nil {
+		return err
+	}
+
+	return c.UpdateClient(
+		ctx,
+		counterparty, source,
+		counterpartyConnection.ClientID,
+	)
+}
+
+// ConnOpenAck initializes a connection on the source chain with the state OPEN
+// using the OpenAck handshake call.
+func (c *Coordinator) ConnOpenAck(
+	ctx context.Context,
+	source, counterparty *Chain,
+	sourceConnection, counterpartyConnection *Connection,
+) error {
+	// set OPEN connection on source using OpenAck
+	if err := source.ConnectionOpenAck(ctx, counterparty, sourceConnection, counterpartyConnection); err != nil {
+		return err
+	}
+
+	if err := source.UpdateHeader(ctx); err != nil {
+		return err
+	}
+
+	// update source client on counterparty connection
+	return c.UpdateClient(
+		ctx,
+		counterparty, source,
+		counterpartyConnection.ClientID,
+	)
+}
+
+// ConnOpenConfirm initializes a connection on the source chain with the state OPEN
+// using the OpenConfirm handshake call.
+func (c *Coordinator) ConnOpenConfirm(
+	ctx context.Context,
+	source, counterparty *Chain,
+	sourceConnection, counterpartyConnection *Connection,
+) error {
+	if err := source.ConnectionOpenConfirm(ctx, counterparty, sourceConnection, counterpartyConnection); err != nil {
+		return err
+	}
+
+	if err := source.UpdateHeader(ctx); err != nil {
+		return err
+	}
+
+	// update source client on counterparty connection
+	return c.UpdateClient(
+		ctx,
+		counterparty, source,
+		counterpartyConnection.ClientID,
+	)
+}
+
+// ChanOpenInit initializes a channel on the source chain with the state INIT
+// using the OpenInit handshake call.
+//
+// NOTE: The counterparty channel will be created even if it is not created in the
+// application state.
+func (c *Coordinator) ChanOpenInit(
+	ctx context.Context,
+	source, counterparty *Chain,
+	connection, counterpartyConnection *Connection,
+	sourcePortID, counterpartyPortID string,
+	order channeltypes.Channel_Order,
+) (Channel, Channel, error) {
+	sourceChannel := source.AddChannel(connection, sourcePortID)
+	counterpartyChannel := counterparty.AddChannel(counterpartyConnection, counterpartyPortID)
+
+	if channelID, err := source.ChannelOpenInit(ctx, sourceChannel, counterpartyChannel, order, connection.ID); err != nil {
+		return sourceChannel, counterpartyChannel, err
+	} else {
+		sourceChannel.ID = channelID
+	}
+
+	if err := source.UpdateHeader(ctx); err != nil {
+		return sourceChannel, counterpartyChannel, err
+	}
+
+	// update source client on counterparty connection
+	err := c.UpdateClient(
+		ctx,
+		counterparty, source,
+		counterpartyConnection.ClientID,
+	)
+	return sourceChannel, counterpartyChannel, err
+}
+
+// ChanOpenTry relays notice of a channel open attempt on chain A to chain B (this
+// code is executed on chain B).
+func (c *Coordinator) ChanOpenTry(
+	ctx context.Context,
+	source, counterparty *Chain,
+	sourceChannel, counterpartyChannel *Channel,
+	connection *Connection,
+	order channeltypes.Channel_Order,
+) error {
+	// initialize channel on source
+	if channelID, err := source.ChannelOpenTry(ctx, counterparty, *sourceChannel, *counterpartyChannel, order, connection.ID); err != nil {
+		return err
+	} else {
+		sourceChannel.ID = channelID
+	}
+	if err := source.UpdateHeader(ctx); err != nil {
+		return err
+	}
+
+	// update source client on counterparty connection
+	return c.UpdateClient(
+		ctx,
+		counterparty, source,
+		connection.CounterpartyClientID,
+	)
+}
+
+// ChanOpenAck relays acceptance of a channel open attempt from chain B back
+// to chain A (this code is executed on chain A).
+func (c *Coordinator) ChanOpenAck(
+	ctx context.Context,
+	source, counterparty *Chain,
+	sourceChannel, counterpartyChannel Channel,
+) error {
+	if err := source.ChannelOpenAck(ctx, counterparty, sourceChannel, counterpartyChannel); err != nil {
+		return err
+	}
+	if err := source.UpdateHeader(ctx); err != nil {
+		return err
+	}
+
+	// update source client on counterparty connection
+	return c.UpdateClient(
+		ctx,
+		counterparty, source,
+		sourceChannel.CounterpartyClientID,
+	)
+}
+
+// ChanOpenConfirm confirms opening of a channel on chain A to chain B, after
+// which the channel is open on both chains (this code is executed on chain B).
+func (c *Coordinator) ChanOpenConfirm(
+	ctx context.Context,
+	source, counterparty *Chain,
+	sourceChannel, counterpartyChannel Channel,
+) error {
+	if err := source.ChannelOpenConfirm(ctx, counterparty, sourceChannel, counterpartyChannel); err != nil {
+		return err
+	}
+	if err := source.UpdateHeader(ctx); err != nil {
+		return err
+	}
+
+	return c.UpdateClient(
+		ctx,
+		counterparty, source,
+		sourceChannel.CounterpartyClientID,
+	)
+}
+
+// ChanCloseInit closes a channel on chain A to chain B (this code is executed on chain A).
+func (c *Coordinator) ChanCloseInit(
+	ctx context.Context,
+	source, counterparty *Chain,
+	sourceChannel Channel,
+) error {
+	if err := source.ChannelCloseInit(ctx, sourceChannel); err != nil {
+		return err
+	}
+	if err := source.UpdateHeader(ctx); err != nil {
+		return err
+	}
+
+	return c.UpdateClient(
+		ctx,
+		counterparty, source,
+		sourceChannel.CounterpartyClientID,
+	)
+}
+
+// ChanCloseConfirm confirms closing of a channel on chain A to chain B, after
+// which the channel is closed on both chains (this code is executed on chain B).
+func (c *Coordinator) ChanCloseConfirm(
+	ctx context.Context,
+	source, counterparty *Chain,
+	sourceChannel, counterpartyChannel Channel,
+) error {
+	if err := source.ChannelCloseConfirm(ctx, counterparty, sourceChannel, counterpartyChannel); err != nil {
+		return err
+	}
+	if err := source.UpdateHeader(ctx); err != nil {
+		return err
+	}
+
+	return c.UpdateClient(
+		ctx,
+		counterparty, source,
+		sourceChannel.CounterpartyClientID,
+	)
+}
+
+// SendPacket sends a packet through the channel keeper on the source chain and updates the
+// counterparty client for the source chain.
+func (c *Coordinator) SendPacket(
+	ctx context.Context,
+	source, counterparty *Chain,
+	packet channeltypes.Packet,
+	counterpartyClientID string,
+) error {
+	if err := source.SendPacket(ctx, packet); err != nil {
+		return err
+	}
+	if err := source.UpdateHeader(ctx); err != nil {
+		return err
+	}
+
+	// update source client on counterparty connection
+	return c.UpdateClient(
+		ctx,
+		counterparty, source,
+		counterpartyClientID,
+	)
+}
+
+func (c *Coordinator) HandlePacketRecv(
+	ctx context.Context,
+	source, counterparty *Chain,
+	sourceChannel, counterpartyChannel Channel,
+	packet channeltypes.Packet,
+) error {
+	if err := source.HandlePacketRecv(ctx, counterparty, sourceChannel, counterpartyChannel, packet); err != nil {
+		return err
+	}
+	if err := source.UpdateHeader(ctx); err != nil {
+		return err
+	}
+
+	// update source client on counterparty connection
+	return c.UpdateClient(
+		ctx,
+		counterparty, source,
+		counterpartyChannel.ClientID,
+	)
+}
+
+func (c *Coordinator) HandlePacketAcknowledgement(
+	ctx context.Context,
+	source, counterparty *Chain,
+	sourceChannel, counterpartyChannel Channel,
+	packet channeltypes.Packet,
+	acknowledgement []byte,
+) error {
+	if err := source.HandlePacketAcknowledgement(ctx, counterparty, sourceChannel, counterpartyChannel, packet, acknowledgement); err != nil {
+		return err
+	}
+	if err := source.UpdateHeader(ctx); err != nil {
+		return err
+	}
+
+	// update source client on counterparty connection
+	return c.UpdateClient(
+		ctx,
+		counterparty, source,
+		counterpartyChannel.ClientID,
+	)
+}
+
+// RelayOrderedPackets relays the packets sent on an ORDERED channel from `counterparty` to `source` in the order of
+// their sequences. The packets that `source` has already received are skipped, and it fails with ErrSequenceGap
+// before relaying any packet if they do not continue from the next receive sequence of `sourceChannel`.
+func (c *Coordinator) RelayOrderedPackets(
+	ctx context.Context,
+	source, counterparty *Chain,
+	sourceChannel, counterpartyChannel Channel,
+	packets []channeltypes.Packet,
+) error {
+	nextSequenceRecv, err := source.QueryNextSequenceRecv(ctx, sourceChannel.PortID, sourceChannel.ID)
+	if err != nil {
+		return err
+	}
+	ordered, err := OrderPackets(packets, nextSequenceRecv)
+	if err != nil {
+		return err
+	}
+	for _, packet := range ordered {
+		if err := c.HandlePacketRecv(ctx, source, counterparty, sourceChannel, counterpartyChannel, packet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TimeoutPacket times out the packet sent on the source chain, which has not been received on the counterparty
+// chain, and updates the counterparty client for the source chain. The client of the counterparty chain on the source
+// chain must have been updated to a height at which the packet has timed out. An ORDERED channel is closed by the
+// timeout.
+func (c *Coordinator) TimeoutPacket(
+	ctx context.Context,
+	source, counterparty *Chain,
+	sourceChannel, counterpartyChannel Channel,
+	packet channeltypes.Packet,
+) error {
+	if err := source.HandlePacketTimeout(ctx, counterparty, sourceChannel, counterpartyChannel, packet); err != nil {
+		return err
+	}
+	if err := source.UpdateHeader(ctx); err != nil {
+		return err
+	}
+
+	// update source client on counterparty connection
+	return c.UpdateClient(
+		ctx,
+		counterparty, source,
+		counterpartyChannel.ClientID,
+	)
+}
+
+// TimeoutOnClose times out the packet sent on the source chain because the counterparty channel has been closed
+// before it receives the packet, and updates the counterparty client for the source chain. The client of the
+// counterparty chain on the source chain must have been updated to a height at which the channel is closed.
+func (c *Coordinator) TimeoutOnClose(
+	ctx context.Context,
+	source, counterparty *Chain,
+	sourceChannel, counterpartyChannel Channel,
+	packet channeltypes.Packet,
+) error {
+	if err := source.HandlePacketTimeoutOnClose(ctx, counterparty, sourceChannel, counterpartyChannel, packet); err != nil {
+		return err
+	}
+	if err := source.UpdateHeader(ctx); err != nil {
+		return err
+	}
+
+	// update source client on counterparty connection
+	return c.UpdateClient(
+		ctx,
+		counterparty, source,
+		counterpartyChannel.ClientID,
+	)
+}
@@ -0,0 +1,80 @@
+package relay
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+
+	"0fatih/yui-ibc-solidity/pkg/contract/ibchandler"
+)
+
+// WatchSendPacket subscribes to the SendPacket events of the IBCHandler. The events are delivered from the block `from`,
+// or from the next block if `from` is nil. The chain must be connected to a websocket or an IPC endpoint.
+func (chain *Chain) WatchSendPacket(ctx context.Context, from *big.Int, sink chan<- *ibchandler.IbchandlerSendPacket) (event.Subscription, error) {
+	return watchIBCHandlerEvent(ctx, chain, abiSendPacket, from, chain.IBCHandler.ParseSendPacket, sink)
+}
+
+// WatchRecvPacket subscribes to the RecvPacket events of the IBCHandler in the same way as WatchSendPacket.
+func (chain *Chain) WatchRecvPacket(ctx context.Context, from *big.Int, sink chan<- *ibchandler.IbchandlerRecvPacket) (event.Subscription, error) {
+	return watchIBCHandlerEvent(ctx, chain, abiRecvPacket, from, chain.IBCHandler.ParseRecvPacket, sink)
+}
+
+// WatchWriteAcknowledgement subscribes to the WriteAcknowledgement events of the IBCHandler in the same way as WatchSendPacket.
+func (chain *Chain) WatchWriteAcknowledgement(ctx context.Context, from *big.Int, sink chan<- *ibchandler.IbchandlerWriteAcknowledgement) (event.Subscription, error) {
+	return watchIBCHandlerEvent(ctx, chain, abiWriteAcknowledgement, from, chain.IBCHandler.ParseWriteAcknowledgement, sink)
+}
+
+// WatchAcknowledgePacket subscribes to the AcknowledgePacket events of the IBCHandler in the same way as WatchSendPacket.
+func (chain *Chain) WatchAcknowledgePacket(ctx context.Context, from *big.Int, sink chan<- *ibchandler.IbchandlerAcknowledgePacket) (event.Subscription, error) {
+	return watchIBCHandlerEvent(ctx, chain, abiAcknowledgePacket, from, chain.IBCHandler.ParseAcknowledgePacket, sink)
+}
+
+// WatchTimeoutPacket subscribes to the TimeoutPacket events of the IBCHandler in the same way as WatchSendPacket.
+func (chain *Chain) WatchTimeoutPacket(ctx context.Context, from *big.Int, sink chan<- *ibchandler.IbchandlerTimeoutPacket) (event.Subscription, error) {
+	return watchIBCHandlerEvent(ctx, chain, abiTimeoutPacket, from, chain.IBCHandler.ParseTimeoutPacket, sink)
+}
+
+// watchIBCHandlerEvent watches the logs of the event with client.WatchLogs, which resubscribes on disconnection,
+// and delivers the parsed events to the sink. The subscription fails if a log cannot be parsed.
+func watchIBCHandlerEvent[T any](ctx context.Context, chain *Chain, ev abi.Event, from *big.Int, parse func(gethtypes.Log) (T, error), sink chan<- T) (event.Subscription, error) {
+	query := ethereum.FilterQuery{
+		FromBlock: from,
+		Addresses: []common.Address{
+			chain.ContractConfig.IBCHandlerAddress,
+		},
+		Topics: [][]common.Hash{{
+			ev.ID,
+		}},
+	}
+	logs := make(chan gethtypes.Log)
+	sub, err := chain.client.WatchLogs(ctx, query, logs)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				parsed, err := parse(log)
+				if err != nil {
+					return err
+				}
+				select {
+				case sink <- parsed:
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
@@ -0,0 +1,255 @@
+package relay
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"0fatih/yui-ibc-solidity/pkg/client"
+	"0fatih/yui-ibc-solidity/pkg/contract/ibchandler"
+	ibft2clienttypes "0fatih/yui-ibc-solidity/pkg/ibc/clients/ibft2"
+	ibcclient "0fatih/yui-ibc-solidity/pkg/ibc/core/client"
+	"0fatih/yui-ibc-solidity/pkg/ibc/core/commitment"
+)
+
+// LightClientModule implements the operations that depend on the type of a light client.
+// A module of the client type X is used to track a chain whose LightClient has the client type X.
+type LightClientModule interface {
+	// ClientType returns the client type registered in the IBCHandler
+	ClientType() string
+	// GetState fetches the header at the height `bn` and the state proof of the storage keys of `address`.
+	// If `bn` is nil, the latest header is fetched. The height of the state has the revision number.
+	GetState(ctx context.Context, cl *client.ETHClient, revisionNumber uint64, address common.Address, storageKeys [][]byte, bn *big.Int) (LightClientState, error)
+	// ConstructMsgCreateClient returns a message to create a client of `counterparty` on `chain`
+	ConstructMsgCreateClient(ctx context.Context, chain, counterparty *Chain) (ibchandler.IBCMsgsMsgCreateClient, error)
+	// ConstructMsgUpdateClient returns a message to update the client of `counterparty` on `chain` to the last header of `counterparty`
+	ConstructMsgUpdateClient(ctx context.Context, chain, counterparty *Chain, clientID string) (ibchandler.IBCMsgsMsgUpdateClient, error)
+	// GetLatestHeight returns the latest height of the client on `chain`
+	GetLatestHeight(ctx context.Context, chain *Chain, clientID string) (ibcclient.Height, error)
+	// ProcessProof converts the storage proof of a commitment into the proof that the client verifies.
+	// `value` returns the value that is committed.
+	ProcessProof(proof *Proof, value func() ([]byte, error)) error
+	// ProcessNonMembershipProof converts the storage proof of an absence into the proof that the client verifies.
+	ProcessNonMembershipProof(proof *Proof) error
+}
+
+// RevisionNumberValidator is implemented by a LightClientModule whose client supports only some revision numbers of
+// the heights of the tracked chain.
+type RevisionNumberValidator interface {
+	// ValidateRevisionNumber returns an error if the client cannot track a chain of the revision number
+	ValidateRevisionNumber(revisionNumber uint64) error
+}
+
+// CommitmentLayoutValidator is implemented by a LightClientModule whose client verifies the proofs at some layouts of
+// the commitments of the tracked IBCHandler only.
+type CommitmentLayoutValidator interface {
+	// ValidateCommitmentLayout returns an error if the client cannot verify the commitments of the layout
+	ValidateCommitmentLayout(layout commitment.Layout) error
+}
+
+var lightClientModules = struct {
+	sync.RWMutex
+	modules map[string]LightClientModule
+}{modules: make(map[string]LightClientModule)}
+
+// init registers the modules of the client types that have a client contract in this repository.
+// The QBFT module is not registered because it has no client contract. (see QBFTLightClientModule)
+func init() {
+	RegisterLightClientModule(mockLightClientModule{})
+	RegisterLightClientModule(ibft2LightClientModule{})
+}
+
+// RegisterLightClientModule registers the module for its client type. It replaces the module that is
+// already registered for the same client type.
+func RegisterLightClientModule(module LightClientModule) {
+	lightClientModules.Lock()
+	defer lightClientModules.Unlock()
+	lightClientModules.modules[module.ClientType()] = module
+}
+
+// GetLightClientModule returns the module registered for the client type.
+func GetLightClientModule(clientType string) (LightClientModule, error) {
+	lightClientModules.RLock()
+	defer lightClientModules.RUnlock()
+	module, ok := lightClientModules.modules[clientType]
+	if !ok {
+		return nil, fmt.Errorf("client type %s is not supported", clientType)
+	}
+	return module, nil
+}
+
+// RegisteredClientTypes returns the sorted client types that have a registered module.
+func RegisteredClientTypes() []string {
+	lightClientModules.RLock()
+	defer lightClientModules.RUnlock()
+	var clientTypes []string
+	for clientType := range lightClientModules.modules {
+		clientTypes = append(clientTypes, clientType)
+	}
+	sort.Strings(clientTypes)
+	return clientTypes
+}
+
+type mockLightClientModule struct{}
+
+var _ LightClientModule = mockLightClientModule{}
+
+func (mockLightClientModule) ClientType() string {
+	return ibcclient.MockClient
+}
+
+func (mockLightClientModule) GetState(ctx context.Context, cl *client.ETHClient, revisionNumber uint64, address common.Address, storageKeys [][]byte, bn *big.Int) (LightClientState, error) {
+	block, err := cl.BlockByNumber(ctx, bn)
+	if err != nil {
+		return nil, err
+	}
+	// this is dummy
+	proof := &client.StateProof{
+		StorageProofRLP: make([][]byte, len(storageKeys)),
+	}
+	return ETHState{header: block.Header(), StateProof: proof, RevisionNumber: revisionNumber}, nil
+}
+
+func (mockLightClientModule) ConstructMsgCreateClient(ctx context.Context, chain, counterparty *Chain) (ibchandler.IBCMsgsMsgCreateClient, error) {
+	return chain.ConstructMockMsgCreateClient(counterparty)
+}
+
+func (mockLightClientModule) ConstructMsgUpdateClient(ctx context.Context, chain, counterparty *Chain, clientID string) (ibchandler.IBCMsgsMsgUpdateClient, error) {
+	return chain.ConstructMockMsgUpdateClient(counterparty, clientID)
+}
+
+func (mockLightClientModule) GetLatestHeight(ctx context.Context, chain *Chain, clientID string) (ibcclient.Height, error) {
+	cs, err := chain.GetMockClientState(ctx, clientID)
+	if err != nil {
+		return ibcclient.Height{}, err
+	}
+	return cs.LatestHeight, nil
+}
+
+// ProcessProof replaces the proof with the sha256 hash of the value, which the mock client compares with.
+func (mockLightClientModule) ProcessProof(proof *Proof, value func() ([]byte, error)) error {
+	bz, err := value()
+	if err != nil {
+		return err
+	}
+	h := sha256.Sum256(bz)
+	proof.Data = h[:]
+	return nil
+}
+
+// ProcessNonMembershipProof empties the proof because the mock client accepts only an empty proof for an absence.
+func (mockLightClientModule) ProcessNonMembershipProof(proof *Proof) error {
+	proof.Data = nil
+	return nil
+}
+
+type ibft2LightClientModule struct{}
+
+var (
+	_ LightClientModule         = ibft2LightClientModule{}
+	_ RevisionNumberValidator   = ibft2LightClientModule{}
+	_ CommitmentLayoutValidator = ibft2LightClientModule{}
+)
+
+func (ibft2LightClientModule) ClientType() string {
+	return ibcclient.BesuIBFT2Client
+}
+
+func (ibft2LightClientModule) GetState(ctx context.Context, cl *client.ETHClient, revisionNumber uint64, address common.Address, storageKeys [][]byte, bn *big.Int) (LightClientState, error) {
+	return getIBFT2State(ctx, cl, revisionNumber, address, storageKeys, bn)
+}
+
+func (ibft2LightClientModule) ConstructMsgCreateClient(ctx context.Context, chain, counterparty *Chain) (ibchandler.IBCMsgsMsgCreateClient, error) {
+	if _, ok := counterparty.LastLCState.(IBFT2State); !ok {
+		return ibchandler.IBCMsgsMsgCreateClient{}, fmt.Errorf("unexpected state type: %T", counterparty.LastLCState)
+	}
+	return chain.ConstructIBFT2MsgCreateClient(counterparty)
+}
+
+func (ibft2LightClientModule) ConstructMsgUpdateClient(ctx context.Context, chain, counterparty *Chain, clientID string) (ibchandler.IBCMsgsMsgUpdateClient, error) {
+	if _, ok := counterparty.LastLCState.(IBFT2State); !ok {
+		return ibchandler.IBCMsgsMsgUpdateClient{}, fmt.Errorf("unexpected state type: %T", counterparty.LastLCState)
+	}
+	return chain.ConstructIBFT2MsgUpdateClient(ctx, counterparty, clientID)
+}
+
+func (ibft2LightClientModule) GetLatestHeight(ctx context.Context, chain *Chain, clientID string) (ibcclient.Height, error) {
+	cs, err := chain.GetIBFT2ClientState(ctx, clientID)
+	if err != nil {
+		return ibcclient.Height{}, err
+	}
+	return cs.LatestHeight, nil
+}
+
+// ValidateRevisionNumber rejects the revision numbers other than 0, because the IBFT2 client builds the heights of
+// the headers from the block numbers only. It is also used by the QBFT module.
+func (ibft2LightClientModule) ValidateRevisionNumber(revisionNumber uint64) error {
+	if revisionNumber != ibft2clienttypes.RevisionNumber {
+		return fmt.Errorf("%w: revision_number=%v", ibft2clienttypes.ErrUnsupportedRevisionNumber, revisionNumber)
+	}
+	return nil
+}
+
+// ValidateCommitmentLayout rejects the commitment slots other than the one fixed by the IBFT2 client. It is also used
+// by the QBFT module.
+func (ibft2LightClientModule) ValidateCommitmentLayout(layout commitment.Layout) error {
+	return ibft2clienttypes.ValidateCommitmentLayout(layout)
+}
+
+// ProcessProof keeps the storage proof as it is because the IBFT2 client verifies it against the state root.
+func (ibft2LightClientModule) ProcessProof(proof *Proof, value func() ([]byte, error)) error {
+	return nil
+}
+
+// ProcessNonMembershipProof keeps the storage proof of the absence as it is.
+func (ibft2LightClientModule) ProcessNonMembershipProof(proof *Proof) error {
+	return nil
+}
+
+// qbftLightClientModule tracks a Besu QBFT chain. It uses the same messages as the IBFT2 client, so the client
+// contract registered for `ibcclient.BesuQBFTClient` must accept QBFT sealing headers in `Header.besu_header_rlp`.
+type qbftLightClientModule struct {
+	ibft2LightClientModule
+}
+
+var _ LightClientModule = qbftLightClientModule{}
+
+// QBFTLightClientModule returns the module of `ibcclient.BesuQBFTClient`. It is not registered by default because
+// this repository has no client contract of the type, so a client of the type cannot be created with the IBCHandler.
+// Please register it with RegisterLightClientModule if the IBCHandlers of the counterparties have a client contract
+// registered for the type.
+func QBFTLightClientModule() LightClientModule {
+	return qbftLightClientModule{}
+}
+
+func (qbftLightClientModule) ClientType() string {
+	return ibcclient.BesuQBFTClient
+}
+
+func (qbftLightClientModule) GetState(ctx context.Context, cl *client.ETHClient, revisionNumber uint64, address common.Address, storageKeys [][]byte, bn *big.Int) (LightClientState, error) {
+	return getQBFTState(ctx, cl, revisionNumber, address, storageKeys, bn)
+}
+
+func (qbftLightClientModule) ConstructMsgCreateClient(ctx context.Context, chain, counterparty *Chain) (ibchandler.IBCMsgsMsgCreateClient, error) {
+	if _, ok := counterparty.LastLCState.(QBFTState); !ok {
+		return ibchandler.IBCMsgsMsgCreateClient{}, fmt.Errorf("unexpected state type: %T", counterparty.LastLCState)
+	}
+	msg, err := chain.ConstructIBFT2MsgCreateClient(counterparty)
+	if err != nil {
+		return ibchandler.IBCMsgsMsgCreateClient{}, err
+	}
+	msg.ClientType = ibcclient.BesuQBFTClient
+	return msg, nil
+}
+
+func (qbftLightClientModule) ConstructMsgUpdateClient(ctx context.Context, chain, counterparty *Chain, clientID string) (ibchandler.IBCMsgsMsgUpdateClient, error) {
+	if _, ok := counterparty.LastLCState.(QBFTState); !ok {
+		return ibchandler.IBCMsgsMsgUpdateClient{}, fmt.Errorf("unexpected state type: %T", counterparty.LastLCState)
+	}
+	return chain.ConstructIBFT2MsgUpdateClient(ctx, counterparty, clientID)
+}
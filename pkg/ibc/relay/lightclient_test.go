@@ -0,0 +1,51 @@
+package relay
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	ibft2clienttypes "0fatih/yui-ibc-solidity/pkg/ibc/clients/ibft2"
+	ibcclient "0fatih/yui-ibc-solidity/pkg/ibc/core/client"
+	"0fatih/yui-ibc-solidity/pkg/ibc/core/commitment"
+)
+
+func TestRegisteredClientTypes(t *testing.T) {
+	// the QBFT module is not registered by default because it has no client contract
+	require.Equal(t, []string{ibcclient.BesuIBFT2Client, ibcclient.MockClient}, RegisteredClientTypes())
+	_, err := GetLightClientModule(ibcclient.BesuQBFTClient)
+	require.Error(t, err)
+}
+
+func TestValidateRevisionNumber(t *testing.T) {
+	// the IBFT2 and QBFT clients build the heights with the revision number 0
+	for _, module := range []LightClientModule{ibft2LightClientModule{}, QBFTLightClientModule()} {
+		v, ok := module.(RevisionNumberValidator)
+		require.True(t, ok, module.ClientType())
+		require.NoError(t, v.ValidateRevisionNumber(0))
+		require.ErrorIs(t, v.ValidateRevisionNumber(ibcclient.ParseChainID("ibc0-1")), ibft2clienttypes.ErrUnsupportedRevisionNumber)
+	}
+
+	// the mock client accepts any revision number
+	module, err := GetLightClientModule(ibcclient.MockClient)
+	require.NoError(t, err)
+	_, ok := module.(RevisionNumberValidator)
+	require.False(t, ok)
+}
+
+func TestValidateCommitmentLayout(t *testing.T) {
+	// the IBFT2 and QBFT clients verify the proofs at the commitment slot of IBFT2Client.sol
+	for _, module := range []LightClientModule{ibft2LightClientModule{}, QBFTLightClientModule()} {
+		v, ok := module.(CommitmentLayoutValidator)
+		require.True(t, ok, module.ClientType())
+		require.NoError(t, v.ValidateCommitmentLayout(commitment.DefaultLayout))
+		require.ErrorIs(t, v.ValidateCommitmentLayout(commitment.NewLayout(common.HexToHash("0x03"))), ibft2clienttypes.ErrUnsupportedCommitmentSlot)
+	}
+
+	// the mock client accepts any layout
+	module, err := GetLightClientModule(ibcclient.MockClient)
+	require.NoError(t, err)
+	_, ok := module.(CommitmentLayoutValidator)
+	require.False(t, ok)
+}
@@ -0,0 +1,38 @@
+package relay
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	channeltypes "0fatih/yui-ibc-solidity/pkg/ibc/core/channel"
+)
+
+// ErrSequenceGap is returned if the packets to relay on an ORDERED channel do not continue from the next receive
+// sequence of the counterparty channel, so the first missing packet must be relayed before them.
+var ErrSequenceGap = errors.New("packet sequence gap")
+
+// OrderPackets returns the packets that an ORDERED channel whose next receive sequence is `nextSequenceRecv` can
+// receive in order. The packets are sorted by sequence, and the ones that have already been received are dropped.
+// It fails with ErrSequenceGap if a sequence is missing, and with an error if a sequence is duplicated.
+func OrderPackets(packets []channeltypes.Packet, nextSequenceRecv uint64) ([]channeltypes.Packet, error) {
+	sorted := make([]channeltypes.Packet, 0, len(packets))
+	for _, p := range packets {
+		if p.Sequence >= nextSequenceRecv {
+			sorted = append(sorted, p)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Sequence < sorted[j].Sequence
+	})
+	expected := nextSequenceRecv
+	for _, p := range sorted {
+		if p.Sequence < expected {
+			return nil, fmt.Errorf("duplicate packet sequence: sequence=%v", p.Sequence)
+		} else if p.Sequence > expected {
+			return nil, fmt.Errorf("%w: expected=%v actual=%v", ErrSequenceGap, expected, p.Sequence)
+		}
+		expected++
+	}
+	return sorted, nil
+}
@@ -0,0 +1,41 @@
+package relay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	channeltypes "0fatih/yui-ibc-solidity/pkg/ibc/core/channel"
+)
+
+func packetsWithSequences(sequences ...uint64) []channeltypes.Packet {
+	var packets []channeltypes.Packet
+	for _, seq := range sequences {
+		packets = append(packets, channeltypes.Packet{Sequence: seq})
+	}
+	return packets
+}
+
+func TestOrderPackets(t *testing.T) {
+	packets, err := OrderPackets(packetsWithSequences(3, 1, 2), 1)
+	require.NoError(t, err)
+	require.Equal(t, packetsWithSequences(1, 2, 3), packets)
+
+	// the received packets are dropped
+	packets, err = OrderPackets(packetsWithSequences(4, 1, 2, 3), 3)
+	require.NoError(t, err)
+	require.Equal(t, packetsWithSequences(3, 4), packets)
+
+	packets, err = OrderPackets(packetsWithSequences(1, 2), 3)
+	require.NoError(t, err)
+	require.Empty(t, packets)
+
+	_, err = OrderPackets(packetsWithSequences(1, 3), 1)
+	require.ErrorIs(t, err, ErrSequenceGap)
+	_, err = OrderPackets(packetsWithSequences(2), 1)
+	require.ErrorIs(t, err, ErrSequenceGap)
+
+	_, err = OrderPackets(packetsWithSequences(1, 2, 2), 1)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrSequenceGap)
+}
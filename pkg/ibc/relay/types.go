@@ -1,34 +1,31 @@
-package testing
+package relay
 
 import (
 	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
 
-	"0fatih/yui-ibc-solidity/pkg/contract/ibchandler"
 	channeltypes "0fatih/yui-ibc-solidity/pkg/ibc/core/channel"
-	commitmenttypes "0fatih/yui-ibc-solidity/pkg/ibc/core/commitment"
-	connectiontypes "0fatih/yui-ibc-solidity/pkg/ibc/core/connection"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/protobuf/types"
 )
 
-// TestConnection is a testing helper struct to keep track of the connectionID, source clientID,
+// Connection is a helper struct to keep track of the connectionID, source clientID,
 // counterparty clientID, and the next channel version used in creating and interacting with a
 // connection.
-type TestConnection struct {
+type Connection struct {
 	ID                   string
 	ClientID             string
 	CounterpartyClientID string
 	NextChannelVersion   string
-	Channels             []TestChannel
+	Channels             []Channel
 }
 
-// TestChannel is a testing helper struct to keep track of the portID and channelID
+// Channel is a helper struct to keep track of the portID and channelID
 // used in creating and interacting with a channel. The clientID and counterparty
 // client ID are also tracked to cut down on querying and argument passing.
-type TestChannel struct {
+type Channel struct {
 	PortID               string
 	ID                   string
 	ClientID             string
@@ -36,35 +33,6 @@ type TestChannel struct {
 	Version              string
 }
 
-func connectionEndToPB(conn ibchandler.ConnectionEndData) *connectiontypes.ConnectionEnd {
-	connpb := &connectiontypes.ConnectionEnd{
-		ClientId:    conn.ClientId,
-		Versions:    []*connectiontypes.Version{},
-		State:       connectiontypes.ConnectionEnd_State(conn.State),
-		DelayPeriod: conn.DelayPeriod,
-		Counterparty: connectiontypes.Counterparty{
-			ClientId:     conn.Counterparty.ClientId,
-			ConnectionId: conn.Counterparty.ConnectionId,
-			Prefix:       commitmenttypes.MerklePrefix(conn.Counterparty.Prefix),
-		},
-	}
-	for _, v := range conn.Versions {
-		ver := connectiontypes.Version(v)
-		connpb.Versions = append(connpb.Versions, &ver)
-	}
-	return connpb
-}
-
-func channelToPB(ch ibchandler.ChannelData) *channeltypes.Channel {
-	return &channeltypes.Channel{
-		State:          channeltypes.Channel_State(ch.State),
-		Ordering:       channeltypes.Channel_Order(ch.Ordering),
-		Counterparty:   channeltypes.Channel_Counterparty(ch.Counterparty),
-		ConnectionHops: ch.ConnectionHops,
-		Version:        ch.Version,
-	}
-}
-
 // uint64ToBigEndian - marshals uint64 to a bigendian byte slice so it can be sorted
 func uint64ToBigEndian(i uint64) []byte {
 	b := make([]byte, 8)
@@ -94,6 +62,12 @@ func commitPacket(packet channeltypes.Packet) []byte {
 	return hash[:]
 }
 
+// commitNextSequenceRecv returns the value whose commitment is stored for the next receive sequence of a channel,
+// which is abi.encodePacked(uint64) in IBCStore.
+func commitNextSequenceRecv(sequence uint64) []byte {
+	return uint64ToBigEndian(sequence)
+}
+
 // commitAcknowledgement returns the hash of commitment bytes
 func commitAcknowledgement(data []byte) []byte {
 	hash := sha256.Sum256(data)
@@ -0,0 +1,442 @@
+// Package index indexes the events of an IBCHandler so that packets and generated identifiers can be looked up
+// without scanning the logs from the genesis block.
+package index
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"0fatih/yui-ibc-solidity/pkg/contract/ibchandler"
+)
+
+// DefaultMaxBlockRange is the default number of blocks that are fetched by an eth_getLogs request.
+const DefaultMaxBlockRange uint64 = 5000
+
+// IdentifierKind is the kind of an identifier generated by the IBCHandler.
+type IdentifierKind string
+
+const (
+	ClientIdentifier     IdentifierKind = "client"
+	ConnectionIdentifier IdentifierKind = "connection"
+	ChannelIdentifier    IdentifierKind = "channel"
+)
+
+// Backend is the subset of ETHClient that the index uses to fetch the logs.
+type Backend interface {
+	ethereum.LogFilterer
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// Packet is a packet sent by the IBCHandler. The destination of the packet is not included in the event.
+type Packet struct {
+	Sequence         uint64       `json:"sequence"`
+	SourcePort       string       `json:"source_port"`
+	SourceChannel    string       `json:"source_channel"`
+	TimeoutHeight    PacketHeight `json:"timeout_height"`
+	TimeoutTimestamp uint64       `json:"timeout_timestamp"`
+	Data             []byte       `json:"data"`
+
+	BlockNumber uint64      `json:"block_number"`
+	TxHash      common.Hash `json:"tx_hash"`
+}
+
+type PacketHeight struct {
+	RevisionNumber uint64 `json:"revision_number"`
+	RevisionHeight uint64 `json:"revision_height"`
+}
+
+// GeneratedIdentifier is an identifier generated by the IBCHandler.
+type GeneratedIdentifier struct {
+	Kind        IdentifierKind `json:"kind"`
+	ID          string         `json:"id"`
+	BlockNumber uint64         `json:"block_number"`
+	TxHash      common.Hash    `json:"tx_hash"`
+}
+
+type packetKey struct {
+	portID    string
+	channelID string
+	sequence  uint64
+}
+
+type channelKey struct {
+	portID    string
+	channelID string
+}
+
+// Index ingests the logs of an IBCHandler incrementally and answers the lookups from memory.
+// If it is opened with a path, the ingested events are appended to the file and loaded on the next Open.
+type Index struct {
+	address       common.Address
+	filterer      *ibchandler.IbchandlerFilterer
+	maxBlockRange uint64
+	confirmations uint64
+	path          string
+
+	// syncMu serializes Sync
+	syncMu sync.Mutex
+
+	// persistedBlock is the last block recorded in the file
+	persistedBlock uint64
+
+	mu        sync.RWMutex
+	nextBlock uint64
+	packets   map[packetKey]*Packet
+	// the sequences of the packets sent on each channel in ascending order
+	sequences map[channelKey][]uint64
+	lastIDs   map[IdentifierKind]*GeneratedIdentifier
+	ids       map[IdentifierKind]map[string]*GeneratedIdentifier
+}
+
+type Option func(*Index)
+
+// WithMaxBlockRange sets the number of blocks that are fetched by an eth_getLogs request.
+func WithMaxBlockRange(n uint64) Option {
+	return func(idx *Index) {
+		idx.maxBlockRange = n
+	}
+}
+
+// WithConfirmations makes the index ingest only the blocks that have the number of confirmations.
+// The index does not handle reorgs, so a chain without instant finality should set it.
+func WithConfirmations(n uint64) Option {
+	return func(idx *Index) {
+		idx.confirmations = n
+	}
+}
+
+// WithStartBlock sets the block from which the logs are ingested. It is ignored if the index is loaded from a file.
+func WithStartBlock(n uint64) Option {
+	return func(idx *Index) {
+		idx.nextBlock = n
+	}
+}
+
+// NewIndex returns an in-memory index of the IBCHandler at the address.
+func NewIndex(address common.Address, opts ...Option) (*Index, error) {
+	filterer, err := ibchandler.NewIbchandlerFilterer(address, nil)
+	if err != nil {
+		return nil, err
+	}
+	idx := &Index{
+		address:       address,
+		filterer:      filterer,
+		maxBlockRange: DefaultMaxBlockRange,
+		packets:       make(map[packetKey]*Packet),
+		sequences:     make(map[channelKey][]uint64),
+		lastIDs:       make(map[IdentifierKind]*GeneratedIdentifier),
+		ids:           make(map[IdentifierKind]map[string]*GeneratedIdentifier),
+	}
+	for _, opt := range opts {
+		opt(idx)
+	}
+	if idx.maxBlockRange == 0 {
+		return nil, errors.New("max block range must be positive")
+	}
+	return idx, nil
+}
+
+// Open returns an index persisted at the path. The file is created if it does not exist.
+func Open(path string, address common.Address, opts ...Option) (*Index, error) {
+	idx, err := NewIndex(address, opts...)
+	if err != nil {
+		return nil, err
+	}
+	idx.path = path
+	if err := idx.load(); err != nil {
+		return nil, err
+	}
+	return idx, nil
+}
+
+// NextBlock returns the first block that is not ingested yet.
+func (idx *Index) NextBlock() uint64 {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.nextBlock
+}
+
+// Sync ingests the logs from the next block to the latest block, fetching at most `maxBlockRange` blocks at a time.
+func (idx *Index) Sync(ctx context.Context, backend Backend) error {
+	idx.syncMu.Lock()
+	defer idx.syncMu.Unlock()
+
+	latest, err := backend.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	if latest < idx.confirmations {
+		return nil
+	}
+	latest -= idx.confirmations
+	for from := idx.NextBlock(); from <= latest; {
+		to := from + idx.maxBlockRange - 1
+		if to > latest {
+			to = latest
+		}
+		b, err := idx.fetch(ctx, backend, from, to)
+		if err != nil {
+			return err
+		}
+		if err := idx.persist(b); err != nil {
+			return err
+		}
+		idx.apply(b)
+		from = to + 1
+	}
+	return nil
+}
+
+// FindPacket returns the packet sent with the sequence on the channel.
+func (idx *Index) FindPacket(portID, channelID string, sequence uint64) (*Packet, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	p, ok := idx.packets[packetKey{portID, channelID, sequence}]
+	return p, ok
+}
+
+// Packets returns at most `limit` packets sent on the channel whose sequences are greater than or equal to
+// `startSequence` in ascending order of the sequence. The sequence of the next page is returned as the second
+// value, which is zero if there are no more packets.
+func (idx *Index) Packets(portID, channelID string, startSequence uint64, limit int) ([]*Packet, uint64) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	seqs := idx.sequences[channelKey{portID, channelID}]
+	i := sort.Search(len(seqs), func(i int) bool { return seqs[i] >= startSequence })
+	var packets []*Packet
+	for ; i < len(seqs) && (limit <= 0 || len(packets) < limit); i++ {
+		packets = append(packets, idx.packets[packetKey{portID, channelID, seqs[i]}])
+	}
+	if i < len(seqs) {
+		return packets, seqs[i]
+	}
+	return packets, 0
+}
+
+// LastGeneratedIdentifier returns the identifier of the kind that was generated last.
+func (idx *Index) LastGeneratedIdentifier(kind IdentifierKind) (*GeneratedIdentifier, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	id, ok := idx.lastIDs[kind]
+	return id, ok
+}
+
+// GeneratedIdentifier returns the generation event of the identifier.
+func (idx *Index) GeneratedIdentifier(kind IdentifierKind, id string) (*GeneratedIdentifier, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	gid, ok := idx.ids[kind][id]
+	return gid, ok
+}
+
+var (
+	topicSendPacket                    common.Hash
+	topicGeneratedClientIdentifier     common.Hash
+	topicGeneratedConnectionIdentifier common.Hash
+	topicGeneratedChannelIdentifier    common.Hash
+
+	// topics are the topics of the indexed events
+	topics []common.Hash
+)
+
+func init() {
+	parsedABI, err := ibchandler.IbchandlerMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	topicSendPacket = parsedABI.Events["SendPacket"].ID
+	topicGeneratedClientIdentifier = parsedABI.Events["GeneratedClientIdentifier"].ID
+	topicGeneratedConnectionIdentifier = parsedABI.Events["GeneratedConnectionIdentifier"].ID
+	topicGeneratedChannelIdentifier = parsedABI.Events["GeneratedChannelIdentifier"].ID
+	topics = []common.Hash{
+		topicSendPacket,
+		topicGeneratedClientIdentifier,
+		topicGeneratedConnectionIdentifier,
+		topicGeneratedChannelIdentifier,
+	}
+}
+
+// batch is the events ingested from a block range. It is also the record of the persisted file.
+type batch struct {
+	ToBlock     uint64                 `json:"to_block"`
+	Packets     []*Packet              `json:"packets,omitempty"`
+	Identifiers []*GeneratedIdentifier `json:"identifiers,omitempty"`
+}
+
+func (idx *Index) fetch(ctx context.Context, backend Backend, from, to uint64) (*batch, error) {
+	logs, err := backend.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{idx.address},
+		Topics:    [][]common.Hash{topics},
+	})
+	if err != nil {
+		return nil, err
+	}
+	b := batch{ToBlock: to}
+	for _, log := range logs {
+		if log.Removed || len(log.Topics) == 0 {
+			continue
+		}
+		if err := idx.parseLog(&b, log); err != nil {
+			return nil, err
+		}
+	}
+	return &b, nil
+}
+
+func (idx *Index) parseLog(b *batch, log gethtypes.Log) error {
+	newID := func(kind IdentifierKind, id string) {
+		b.Identifiers = append(b.Identifiers, &GeneratedIdentifier{Kind: kind, ID: id, BlockNumber: log.BlockNumber, TxHash: log.TxHash})
+	}
+	switch log.Topics[0] {
+	case topicSendPacket:
+		ev, err := idx.filterer.ParseSendPacket(log)
+		if err != nil {
+			return err
+		}
+		b.Packets = append(b.Packets, &Packet{
+			Sequence:         ev.Sequence,
+			SourcePort:       ev.SourcePort,
+			SourceChannel:    ev.SourceChannel,
+			TimeoutHeight:    PacketHeight(ev.TimeoutHeight),
+			TimeoutTimestamp: ev.TimeoutTimestamp,
+			Data:             ev.Data,
+			BlockNumber:      log.BlockNumber,
+			TxHash:           log.TxHash,
+		})
+	case topicGeneratedClientIdentifier:
+		ev, err := idx.filterer.ParseGeneratedClientIdentifier(log)
+		if err != nil {
+			return err
+		}
+		newID(ClientIdentifier, ev.Arg0)
+	case topicGeneratedConnectionIdentifier:
+		ev, err := idx.filterer.ParseGeneratedConnectionIdentifier(log)
+		if err != nil {
+			return err
+		}
+		newID(ConnectionIdentifier, ev.Arg0)
+	case topicGeneratedChannelIdentifier:
+		ev, err := idx.filterer.ParseGeneratedChannelIdentifier(log)
+		if err != nil {
+			return err
+		}
+		newID(ChannelIdentifier, ev.Arg0)
+	default:
+		return fmt.Errorf("unexpected topic: %v", log.Topics[0])
+	}
+	return nil
+}
+
+func (idx *Index) apply(b *batch) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for _, p := range b.Packets {
+		key := packetKey{p.SourcePort, p.SourceChannel, p.Sequence}
+		if _, ok := idx.packets[key]; ok {
+			continue
+		}
+		idx.packets[key] = p
+		ck := channelKey{p.SourcePort, p.SourceChannel}
+		seqs := idx.sequences[ck]
+		// the sequences are ascending on a channel, so the insertion is usually an append
+		i := sort.Search(len(seqs), func(i int) bool { return seqs[i] >= p.Sequence })
+		seqs = append(seqs, 0)
+		copy(seqs[i+1:], seqs[i:])
+		seqs[i] = p.Sequence
+		idx.sequences[ck] = seqs
+	}
+	for _, id := range b.Identifiers {
+		idx.lastIDs[id.Kind] = id
+		if idx.ids[id.Kind] == nil {
+			idx.ids[id.Kind] = make(map[string]*GeneratedIdentifier)
+		}
+		idx.ids[id.Kind][id.ID] = id
+	}
+	if b.ToBlock+1 > idx.nextBlock {
+		idx.nextBlock = b.ToBlock + 1
+	}
+}
+
+// persist appends the batch to the file as a JSON line. An empty batch is written only if `maxBlockRange` blocks
+// have passed since the last record, which bounds the blocks that are fetched again after reopening. If the write
+// fails, the file is truncated to the last complete line, so that the next batch is not appended to a partial one.
+func (idx *Index) persist(b *batch) error {
+	if idx.path == "" {
+		return nil
+	} else if len(b.Packets) == 0 && len(b.Identifiers) == 0 && b.ToBlock < idx.persistedBlock+idx.maxBlockRange {
+		return nil
+	}
+	bz, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(idx.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.Write(append(bz, '\n')); err != nil {
+		if truncErr := f.Truncate(info.Size()); truncErr != nil {
+			err = fmt.Errorf("%w (failed to truncate the index: %v)", err, truncErr)
+		}
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	idx.persistedBlock = b.ToBlock
+	return nil
+}
+
+// load applies the batches in the file. A batch is persisted only if its line is terminated, so a partial last line,
+// which is left by a crash while appending, is truncated and the blocks after the last complete batch are fetched
+// again by the next Sync. A corrupt complete line is an error.
+func (idx *Index) load() error {
+	f, err := os.Open(idx.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+	// the end of the last complete line
+	var offset int64
+	for line := 1; ; line++ {
+		bz, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if len(bz) == 0 {
+				return nil
+			}
+			return os.Truncate(idx.path, offset)
+		} else if err != nil {
+			return err
+		}
+		var b batch
+		if err := json.Unmarshal(bz, &b); err != nil {
+			return fmt.Errorf("failed to load the index: path=%v line=%v: %w", idx.path, line, err)
+		}
+		idx.apply(&b)
+		idx.persistedBlock = b.ToBlock
+		offset += int64(len(bz))
+	}
+}
@@ -0,0 +1,203 @@
+package index
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"0fatih/yui-ibc-solidity/pkg/contract/ibchandler"
+)
+
+var testHandlerAddress = common.HexToAddress("0xaa43d337145E8930d01cb4E60Abf6595C692921E")
+
+// fakeBackend serves the logs of the blocks and records the requested block ranges.
+type fakeBackend struct {
+	blocks [][]gethtypes.Log
+	ranges [][2]uint64
+}
+
+func (b *fakeBackend) BlockNumber(ctx context.Context) (uint64, error) {
+	return uint64(len(b.blocks)) - 1, nil
+}
+
+func (b *fakeBackend) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]gethtypes.Log, error) {
+	from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	b.ranges = append(b.ranges, [2]uint64{from, to})
+	var logs []gethtypes.Log
+	for _, block := range b.blocks[from : to+1] {
+		logs = append(logs, block...)
+	}
+	return logs, nil
+}
+
+func (b *fakeBackend) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- gethtypes.Log) (ethereum.Subscription, error) {
+	panic("not supported")
+}
+
+func (b *fakeBackend) mine(t *testing.T, events ...func(t *testing.T) gethtypes.Log) {
+	number := uint64(len(b.blocks))
+	var logs []gethtypes.Log
+	for i, ev := range events {
+		log := ev(t)
+		log.Address = testHandlerAddress
+		log.BlockNumber = number
+		log.Index = uint(i)
+		logs = append(logs, log)
+	}
+	b.blocks = append(b.blocks, logs)
+}
+
+func sendPacket(port, channel string, sequence uint64) func(t *testing.T) gethtypes.Log {
+	return func(t *testing.T) gethtypes.Log {
+		return makeLog(t, "SendPacket", sequence, port, channel, ibchandler.HeightData{RevisionHeight: 100}, uint64(0), []byte("data"))
+	}
+}
+
+func generatedID(event string, id string) func(t *testing.T) gethtypes.Log {
+	return func(t *testing.T) gethtypes.Log {
+		return makeLog(t, event, id)
+	}
+}
+
+func makeLog(t *testing.T, event string, args ...interface{}) gethtypes.Log {
+	parsedABI, err := ibchandler.IbchandlerMetaData.GetAbi()
+	require.NoError(t, err)
+	ev := parsedABI.Events[event]
+	data, err := ev.Inputs.NonIndexed().Pack(args...)
+	require.NoError(t, err)
+	return gethtypes.Log{Topics: []common.Hash{ev.ID}, Data: data}
+}
+
+func TestIndex(t *testing.T) {
+	ctx := context.Background()
+	backend := &fakeBackend{}
+	backend.mine(t, generatedID("GeneratedClientIdentifier", "mock-client-0"))
+	backend.mine(t, generatedID("GeneratedConnectionIdentifier", "connection-0"), generatedID("GeneratedChannelIdentifier", "channel-0"))
+	backend.mine(t)
+	for i := uint64(1); i <= 5; i++ {
+		backend.mine(t, sendPacket("transfer", "channel-0", i), sendPacket("transfer", "channel-1", i))
+	}
+
+	path := filepath.Join(t.TempDir(), "index")
+	idx, err := Open(path, testHandlerAddress, WithMaxBlockRange(3))
+	require.NoError(t, err)
+	require.NoError(t, idx.Sync(ctx, backend))
+	require.Equal(t, [][2]uint64{{0, 2}, {3, 5}, {6, 7}}, backend.ranges)
+	require.Equal(t, uint64(8), idx.NextBlock())
+
+	id, ok := idx.LastGeneratedIdentifier(ChannelIdentifier)
+	require.True(t, ok)
+	require.Equal(t, "channel-0", id.ID)
+	require.Equal(t, uint64(1), id.BlockNumber)
+	_, ok = idx.GeneratedIdentifier(ClientIdentifier, "mock-client-0")
+	require.True(t, ok)
+
+	p, ok := idx.FindPacket("transfer", "channel-1", 3)
+	require.True(t, ok)
+	require.Equal(t, uint64(5), p.BlockNumber)
+	require.Equal(t, []byte("data"), p.Data)
+	require.Equal(t, uint64(100), p.TimeoutHeight.RevisionHeight)
+	_, ok = idx.FindPacket("transfer", "channel-1", 6)
+	require.False(t, ok)
+
+	// pagination
+	packets, next := idx.Packets("transfer", "channel-0", 0, 2)
+	require.Len(t, packets, 2)
+	require.Equal(t, uint64(3), next)
+	packets, next = idx.Packets("transfer", "channel-0", next, 2)
+	require.Equal(t, []uint64{3, 4}, sequences(packets))
+	packets, next = idx.Packets("transfer", "channel-0", next, 2)
+	require.Equal(t, []uint64{5}, sequences(packets))
+	require.Zero(t, next)
+
+	// only the new blocks are fetched
+	backend.ranges = nil
+	backend.mine(t, sendPacket("transfer", "channel-0", 6))
+	require.NoError(t, idx.Sync(ctx, backend))
+	require.Equal(t, [][2]uint64{{8, 8}}, backend.ranges)
+
+	// the reopened index continues from the persisted events
+	idx, err = Open(path, testHandlerAddress, WithMaxBlockRange(3))
+	require.NoError(t, err)
+	require.Equal(t, uint64(9), idx.NextBlock())
+	_, ok = idx.FindPacket("transfer", "channel-0", 6)
+	require.True(t, ok)
+	id, ok = idx.LastGeneratedIdentifier(ClientIdentifier)
+	require.True(t, ok)
+	require.Equal(t, "mock-client-0", id.ID)
+}
+
+func TestIndexPartialLine(t *testing.T) {
+	ctx := context.Background()
+	backend := &fakeBackend{}
+	backend.mine(t, sendPacket("transfer", "channel-0", 1))
+	backend.mine(t, sendPacket("transfer", "channel-0", 2))
+
+	path := filepath.Join(t.TempDir(), "index")
+	idx, err := Open(path, testHandlerAddress, WithMaxBlockRange(1))
+	require.NoError(t, err)
+	require.NoError(t, idx.Sync(ctx, backend))
+	bz, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	// a crash while appending the second batch leaves a partial line
+	lines := bytes.SplitAfter(bz, []byte("\n"))
+	require.Len(t, lines, 3)
+	partial := append(common.CopyBytes(lines[0]), lines[1][:len(lines[1])/2]...)
+	require.NoError(t, os.WriteFile(path, partial, 0o644))
+
+	// the partial line is discarded and its blocks are fetched again
+	idx, err = Open(path, testHandlerAddress, WithMaxBlockRange(1))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), idx.NextBlock())
+	_, ok := idx.FindPacket("transfer", "channel-0", 2)
+	require.False(t, ok)
+	backend.ranges = nil
+	require.NoError(t, idx.Sync(ctx, backend))
+	require.Equal(t, [][2]uint64{{1, 1}}, backend.ranges)
+	_, ok = idx.FindPacket("transfer", "channel-0", 2)
+	require.True(t, ok)
+
+	// the file is appended after the last complete line
+	idx, err = Open(path, testHandlerAddress, WithMaxBlockRange(1))
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), idx.NextBlock())
+	bz, err = os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(lines[0])+string(lines[1]), string(bz))
+
+	// a corrupt complete line is an error
+	require.NoError(t, os.WriteFile(path, append(partial, '\n'), 0o644))
+	_, err = Open(path, testHandlerAddress)
+	require.Error(t, err)
+}
+
+func TestIndexConfirmations(t *testing.T) {
+	ctx := context.Background()
+	backend := &fakeBackend{}
+	backend.mine(t, sendPacket("transfer", "channel-0", 1))
+	backend.mine(t, sendPacket("transfer", "channel-0", 2))
+
+	idx, err := NewIndex(testHandlerAddress, WithConfirmations(1))
+	require.NoError(t, err)
+	require.NoError(t, idx.Sync(ctx, backend))
+	_, ok := idx.FindPacket("transfer", "channel-0", 1)
+	require.True(t, ok)
+	_, ok = idx.FindPacket("transfer", "channel-0", 2)
+	require.False(t, ok)
+}
+
+func sequences(packets []*Packet) []uint64 {
+	var seqs []uint64
+	for _, p := range packets {
+		seqs = append(seqs, p.Sequence)
+	}
+	return seqs
+}
@@ -0,0 +1,129 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// RemoteAPI is the JSON-RPC API of a remote signer.
+type RemoteAPI int
+
+const (
+	// ClefAPI signs with account_signTransaction of Clef
+	ClefAPI RemoteAPI = iota
+	// Web3SignerAPI signs with eth_signTransaction of Web3Signer
+	Web3SignerAPI
+)
+
+// ParseRemoteAPI parses "clef" or "web3signer".
+func ParseRemoteAPI(s string) (RemoteAPI, error) {
+	switch s {
+	case "clef":
+		return ClefAPI, nil
+	case "web3signer":
+		return Web3SignerAPI, nil
+	default:
+		return 0, fmt.Errorf("unknown remote signer API: %v", s)
+	}
+}
+
+// RemoteSigner asks a remote signer to sign the transactions of an account, so the key never leaves the signer.
+type RemoteSigner struct {
+	client  *rpc.Client
+	address common.Address
+	api     RemoteAPI
+}
+
+var _ Signer = (*RemoteSigner)(nil)
+
+// DialRemoteSigner connects to the endpoint of the remote signer that holds the key of the address.
+func DialRemoteSigner(ctx context.Context, endpoint string, address common.Address, api RemoteAPI) (*RemoteSigner, error) {
+	client, err := rpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return NewRemoteSigner(client, address, api), nil
+}
+
+func NewRemoteSigner(client *rpc.Client, address common.Address, api RemoteAPI) *RemoteSigner {
+	return &RemoteSigner{client: client, address: address, api: api}
+}
+
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+// sendTxArgs is the transaction in the format of the signing methods of Clef and Web3Signer.
+type sendTxArgs struct {
+	From                 common.Address        `json:"from"`
+	To                   *common.Address       `json:"to,omitempty"`
+	Gas                  hexutil.Uint64        `json:"gas"`
+	GasPrice             *hexutil.Big          `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big          `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big          `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big          `json:"value"`
+	Nonce                hexutil.Uint64        `json:"nonce"`
+	Data                 hexutil.Bytes         `json:"data"`
+	AccessList           *gethtypes.AccessList `json:"accessList,omitempty"`
+	ChainID              *hexutil.Big          `json:"chainId"`
+}
+
+func (s *RemoteSigner) SignTx(ctx context.Context, chainID *big.Int, tx *gethtypes.Transaction) (*gethtypes.Transaction, error) {
+	args := sendTxArgs{
+		From:    s.address,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   (*hexutil.Big)(tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    tx.Data(),
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	switch tx.Type() {
+	case gethtypes.LegacyTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case gethtypes.DynamicFeeTxType:
+		accessList := tx.AccessList()
+		args.MaxFeePerGas, args.MaxPriorityFeePerGas, args.AccessList = (*hexutil.Big)(tx.GasFeeCap()), (*hexutil.Big)(tx.GasTipCap()), &accessList
+	default:
+		return nil, fmt.Errorf("unsupported transaction type: %v", tx.Type())
+	}
+
+	var raw hexutil.Bytes
+	switch s.api {
+	case ClefAPI:
+		var result struct {
+			Raw hexutil.Bytes `json:"raw"`
+		}
+		if err := s.client.CallContext(ctx, &result, "account_signTransaction", args); err != nil {
+			return nil, err
+		}
+		raw = result.Raw
+	case Web3SignerAPI:
+		if err := s.client.CallContext(ctx, &raw, "eth_signTransaction", args); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown remote signer API: %v", s.api)
+	}
+
+	signed := new(gethtypes.Transaction)
+	if err := signed.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("failed to decode the signed transaction: %w", err)
+	}
+	// ensure that the signer signed the requested transaction with the expected key
+	signer := gethtypes.LatestSignerForChainID(chainID)
+	if from, err := gethtypes.Sender(signer, signed); err != nil {
+		return nil, err
+	} else if from != s.address {
+		return nil, fmt.Errorf("the transaction is signed by an unexpected account: expected=%v actual=%v", s.address, from)
+	} else if signer.Hash(signed) != signer.Hash(tx) {
+		return nil, fmt.Errorf("the remote signer changed the transaction: %v", signed.Hash())
+	}
+	return signed, nil
+}
@@ -0,0 +1,141 @@
+// Package signer provides the sources of the keys that sign transactions: raw private keys, encrypted keystore
+// files, keys derived from a mnemonic and remote signers such as Clef and Web3Signer.
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"0fatih/yui-ibc-solidity/pkg/wallet"
+)
+
+// Signer signs the transactions of an account.
+type Signer interface {
+	// Address returns the address of the account
+	Address() common.Address
+	// SignTx signs the transaction for the chain
+	SignTx(ctx context.Context, chainID *big.Int, tx *gethtypes.Transaction) (*gethtypes.Transaction, error)
+}
+
+// Keyring returns the signers of the accounts identified by indexes, e.g. the key of the relayer.
+type Keyring interface {
+	Signer(index uint32) (Signer, error)
+}
+
+// TransactOpts returns the options of the contract bindings that sign the transactions with the signer.
+func TransactOpts(ctx context.Context, s Signer, chainID *big.Int) *bind.TransactOpts {
+	addr := s.Address()
+	return &bind.TransactOpts{
+		From: addr,
+		Signer: func(address common.Address, tx *gethtypes.Transaction) (*gethtypes.Transaction, error) {
+			if address != addr {
+				return nil, errors.New("not authorized to sign this account")
+			}
+			return s.SignTx(ctx, chainID, tx)
+		},
+	}
+}
+
+// PrivateKeySigner signs the transactions with a private key in memory.
+type PrivateKeySigner struct {
+	key *ecdsa.PrivateKey
+}
+
+var _ Signer = (*PrivateKeySigner)(nil)
+
+func NewPrivateKeySigner(key *ecdsa.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{key: key}
+}
+
+// NewPrivateKeySignerFromHex returns a signer of the hex-encoded private key with or without the prefix "0x".
+func NewPrivateKeySignerFromHex(hexKey string) (*PrivateKeySigner, error) {
+	key, err := gethcrypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(hexKey), "0x"))
+	if err != nil {
+		return nil, err
+	}
+	return NewPrivateKeySigner(key), nil
+}
+
+// NewKeystoreSigner decrypts the encrypted keystore file of go-ethereum with the passphrase.
+func NewKeystoreSigner(path, passphrase string) (*PrivateKeySigner, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keystore.DecryptKey(bz, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the keystore '%v': %w", path, err)
+	}
+	return NewPrivateKeySigner(key.PrivateKey), nil
+}
+
+func (s *PrivateKeySigner) Address() common.Address {
+	return gethcrypto.PubkeyToAddress(s.key.PublicKey)
+}
+
+func (s *PrivateKeySigner) SignTx(ctx context.Context, chainID *big.Int, tx *gethtypes.Transaction) (*gethtypes.Transaction, error) {
+	return gethtypes.SignTx(tx, gethtypes.LatestSignerForChainID(chainID), s.key)
+}
+
+// MnemonicKeyring derives the key of an index at the base path extended with the index from the mnemonic.
+type MnemonicKeyring struct {
+	mnemonic   string
+	passphrase string
+	base       wallet.HDPath
+
+	mtx     sync.Mutex
+	signers map[uint32]Signer
+}
+
+var _ Keyring = (*MnemonicKeyring)(nil)
+
+// NewMnemonicKeyring returns a keyring that derives the key of an index at m/44'/60'/0'/0/{index}.
+func NewMnemonicKeyring(mnemonic string) *MnemonicKeyring {
+	return NewHDKeyring(mnemonic, "", wallet.DefaultBasePath)
+}
+
+// NewHDKeyring returns a keyring that derives the key of an index at the base path extended with the index
+// from the mnemonic and the BIP-39 passphrase.
+func NewHDKeyring(mnemonic, passphrase string, base wallet.HDPath) *MnemonicKeyring {
+	return &MnemonicKeyring{mnemonic: mnemonic, passphrase: passphrase, base: base, signers: make(map[uint32]Signer)}
+}
+
+func (k *MnemonicKeyring) Signer(index uint32) (Signer, error) {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	if s, ok := k.signers[index]; ok {
+		return s, nil
+	}
+	key, err := wallet.GetPrvKeyFromMnemonicAndPassphrase(k.mnemonic, k.passphrase, k.base.Child(index).String())
+	if err != nil {
+		return nil, err
+	}
+	s := NewPrivateKeySigner(key)
+	k.signers[index] = s
+	return s, nil
+}
+
+// Keys is a Keyring of the fixed signers.
+type Keys map[uint32]Signer
+
+var _ Keyring = Keys(nil)
+
+func (k Keys) Signer(index uint32) (Signer, error) {
+	s, ok := k[index]
+	if !ok {
+		return nil, fmt.Errorf("no signer for the key index: %v", index)
+	}
+	return s, nil
+}
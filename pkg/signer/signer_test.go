@@ -0,0 +1,152 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"0fatih/yui-ibc-solidity/pkg/wallet"
+)
+
+const testMnemonic = "math razor capable expose worth grape metal sunset metal sudden usage scheme"
+
+var testChainID = big.NewInt(1337)
+
+func newTestTx() *gethtypes.Transaction {
+	to := common.Address{2}
+	return gethtypes.NewTx(&gethtypes.DynamicFeeTx{ChainID: testChainID, Nonce: 1, To: &to, Gas: 21000, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(2), Value: big.NewInt(3), Data: []byte{4}})
+}
+
+func requireSignedBy(t *testing.T, address common.Address, tx *gethtypes.Transaction) {
+	from, err := gethtypes.Sender(gethtypes.LatestSignerForChainID(testChainID), tx)
+	require.NoError(t, err)
+	require.Equal(t, address, from)
+}
+
+func TestPrivateKeySigner(t *testing.T) {
+	ctx := context.Background()
+	key, err := gethcrypto.GenerateKey()
+	require.NoError(t, err)
+	s, err := NewPrivateKeySignerFromHex(hexutil.Encode(gethcrypto.FromECDSA(key)))
+	require.NoError(t, err)
+	require.Equal(t, gethcrypto.PubkeyToAddress(key.PublicKey), s.Address())
+
+	opts := TransactOpts(ctx, s, testChainID)
+	tx, err := opts.Signer(s.Address(), newTestTx())
+	require.NoError(t, err)
+	requireSignedBy(t, s.Address(), tx)
+	_, err = opts.Signer(common.Address{1}, newTestTx())
+	require.Error(t, err)
+
+	// keystore
+	bz, err := keystore.EncryptKey(&keystore.Key{Address: s.Address(), PrivateKey: key}, "passphrase", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "key.json")
+	require.NoError(t, os.WriteFile(path, bz, 0o600))
+	ks, err := NewKeystoreSigner(path, "passphrase")
+	require.NoError(t, err)
+	require.Equal(t, s.Address(), ks.Address())
+	_, err = NewKeystoreSigner(path, "wrong")
+	require.Error(t, err)
+}
+
+func TestKeyring(t *testing.T) {
+	key, err := wallet.GetPrvKeyFromMnemonicAndHDWPath(testMnemonic, "m/44'/60'/0'/0/1")
+	require.NoError(t, err)
+	s, err := NewMnemonicKeyring(testMnemonic).Signer(1)
+	require.NoError(t, err)
+	require.Equal(t, gethcrypto.PubkeyToAddress(key.PublicKey), s.Address())
+
+	keys := Keys{1: s}
+	s1, err := keys.Signer(1)
+	require.NoError(t, err)
+	require.Equal(t, s, s1)
+	_, err = keys.Signer(0)
+	require.Error(t, err)
+}
+
+// remoteSignerAPI is a stub of Clef and Web3Signer that signs with a local key.
+type remoteSignerAPI struct {
+	key *ecdsa.PrivateKey
+	// tamper changes the transaction before it is signed
+	tamper bool
+}
+
+func (api *remoteSignerAPI) sign(args sendTxArgs) (hexutil.Bytes, error) {
+	nonce := uint64(args.Nonce)
+	if api.tamper {
+		nonce++
+	}
+	tx := gethtypes.NewTx(&gethtypes.DynamicFeeTx{
+		ChainID:   args.ChainID.ToInt(),
+		Nonce:     nonce,
+		To:        args.To,
+		Gas:       uint64(args.Gas),
+		GasTipCap: args.MaxPriorityFeePerGas.ToInt(),
+		GasFeeCap: args.MaxFeePerGas.ToInt(),
+		Value:     args.Value.ToInt(),
+		Data:      args.Data,
+	})
+	signed, err := gethtypes.SignTx(tx, gethtypes.LatestSignerForChainID(args.ChainID.ToInt()), api.key)
+	if err != nil {
+		return nil, err
+	}
+	return signed.MarshalBinary()
+}
+
+type clefAPI struct{ *remoteSignerAPI }
+
+func (api clefAPI) SignTransaction(args sendTxArgs) (map[string]interface{}, error) {
+	raw, err := api.sign(args)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"raw": raw}, nil
+}
+
+type web3SignerAPI struct{ *remoteSignerAPI }
+
+func (api web3SignerAPI) SignTransaction(args sendTxArgs) (hexutil.Bytes, error) {
+	return api.sign(args)
+}
+
+func TestRemoteSigner(t *testing.T) {
+	ctx := context.Background()
+	key, err := gethcrypto.GenerateKey()
+	require.NoError(t, err)
+	address := gethcrypto.PubkeyToAddress(key.PublicKey)
+	stub := &remoteSignerAPI{key: key}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("account", clefAPI{stub}))
+	require.NoError(t, server.RegisterName("eth", web3SignerAPI{stub}))
+	defer server.Stop()
+
+	for _, api := range []RemoteAPI{ClefAPI, Web3SignerAPI} {
+		stub.tamper = false
+		s := NewRemoteSigner(rpc.DialInProc(server), address, api)
+		tx, err := s.SignTx(ctx, testChainID, newTestTx())
+		require.NoError(t, err)
+		requireSignedBy(t, address, tx)
+		require.Equal(t, newTestTx().Nonce(), tx.Nonce())
+
+		// the signer must not change the transaction
+		stub.tamper = true
+		_, err = s.SignTx(ctx, testChainID, newTestTx())
+		require.Error(t, err)
+
+		// the signer must sign with the key of the address
+		_, err = NewRemoteSigner(rpc.DialInProc(server), common.Address{1}, api).SignTx(ctx, testChainID, newTestTx())
+		require.Error(t, err)
+	}
+}
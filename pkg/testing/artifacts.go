@@ -0,0 +1,66 @@
+package testing
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ErrArtifactNotFound is returned if the artifact of a contract is not embedded.
+var ErrArtifactNotFound = errors.New("contract artifact not found")
+
+// The artifacts are generated from the forge build output, which requires forge and jq.
+//go:generate make -C ../.. simulated-artifacts
+
+//go:embed artifacts
+var artifactsFS embed.FS
+
+// ContractArtifact is the ABI and the creation bytecode of a contract.
+type ContractArtifact struct {
+	ABI      abi.ABI
+	Bytecode []byte
+}
+
+// forgeArtifact is a subset of the JSON that forge writes to `out/<File>.sol/<Contract>.json`.
+type forgeArtifact struct {
+	ABI      json.RawMessage `json:"abi"`
+	Bytecode struct {
+		Object string `json:"object"`
+	} `json:"bytecode"`
+}
+
+// LoadContractArtifact loads the embedded artifact of the contract with the given name.
+func LoadContractArtifact(name string) (*ContractArtifact, error) {
+	bz, err := artifactsFS.ReadFile(path.Join("artifacts", name+".json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %v", ErrArtifactNotFound, name)
+	} else if err != nil {
+		return nil, err
+	}
+	return parseForgeArtifact(bz)
+}
+
+func parseForgeArtifact(bz []byte) (*ContractArtifact, error) {
+	var fa forgeArtifact
+	if err := json.Unmarshal(bz, &fa); err != nil {
+		return nil, err
+	}
+	contractABI, err := abi.JSON(strings.NewReader(string(fa.ABI)))
+	if err != nil {
+		return nil, err
+	}
+	bytecode, err := hexutil.Decode(fa.Bytecode.Object)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bytecode: %w", err)
+	} else if len(bytecode) == 0 {
+		return nil, errors.New("bytecode is empty")
+	}
+	return &ContractArtifact{ABI: contractABI, Bytecode: bytecode}, nil
+}
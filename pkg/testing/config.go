@@ -1,78 +1,55 @@
 package testing
 
 import (
-	"encoding/json"
-	"errors"
-	"os"
+	"context"
+	"path/filepath"
 
-	"github.com/ethereum/go-ethereum/common"
+	"0fatih/yui-ibc-solidity/pkg/client"
+	"0fatih/yui-ibc-solidity/pkg/ibc/relay"
 )
 
-type ContractConfig struct {
-	IBCHandlerAddress              common.Address
-	ICS20TransferBankAddress       common.Address
-	ICS20BankAddress               common.Address
-	IBCCommitmentTestHelperAddress common.Address
-	ERC20TokenAddress              common.Address
-}
-
-func (cc *ContractConfig) Validate() error {
-	var zero common.Address
-	if cc.IBCHandlerAddress == zero {
-		return errors.New("IBCHandlerAddress is empty")
-	} else if cc.ICS20TransferBankAddress == zero {
-		return errors.New("ICS20TransferBankAddress is empty")
-	} else if cc.ICS20BankAddress == zero {
-		return errors.New("ICS20BankAddress is empty")
-	} else if cc.IBCCommitmentTestHelperAddress == zero {
-		return errors.New("IBCCommitmentTestHelperAddress is empty")
-	} else if cc.ERC20TokenAddress == zero {
-		return errors.New("ERC20TokenAddress is empty")
-	} else {
-		return nil
-	}
-}
-
-type BroadcastLog struct {
-	Transactions []Transaction `json:"transactions"`
-}
-
-type Transaction struct {
-	TransactionType string         `json:"transactionType"`
-	ContractName    string         `json:"contractName"`
-	ContractAddress common.Address `json:"contractAddress"`
-}
-
-func buildContractConfigFromBroadcastLog(path string) (*ContractConfig, error) {
-	bz, err := os.ReadFile(path)
+// LoadContractConfig loads the config of the chain from the sources specified by the environ variables:
+//
+//   - TEST_CONTRACT_CONFIG: a JSON or YAML file of the config
+//   - TEST_HARDHAT_DEPLOYMENTS_DIR: the directory of the hardhat-deploy deployments of the network
+//   - TEST_TRUFFLE_BUILD_DIR: the directory of the truffle artifacts, whose network ID is the chain ID
+//   - TEST_BROADCAST_LOG_DIR: the directory of the forge broadcast logs, which has "{chainID}/run-latest.json"
+//
+// The first one that is set is used, and then the addresses are overridden by the environ variables with the prefix
+// "TEST_", e.g. TEST_IBC_HANDLER_ADDRESS. Finally, the ICS-20 contracts that are not found are discovered from the IBCHandler,
+// whose commitment layout is resolved with relay.ResolveCommitmentLayout.
+func LoadContractConfig(ctx context.Context, cl *client.ETHClient, getenv func(string) string, names relay.ContractNames) (*relay.ContractConfig, error) {
+	chainID, err := cl.ChainID(ctx)
 	if err != nil {
 		return nil, err
 	}
-	var cc ContractConfig
-
-	var log BroadcastLog
-	if err := json.Unmarshal(bz, &log); err != nil {
+	cc := &relay.ContractConfig{}
+	if path := getenv("TEST_CONTRACT_CONFIG"); path != "" {
+		cc, err = relay.LoadContractConfigFile(path)
+	} else if dir := getenv("TEST_HARDHAT_DEPLOYMENTS_DIR"); dir != "" {
+		cc, err = relay.ContractConfigFromHardhatDeployments(dir, names)
+	} else if dir := getenv("TEST_TRUFFLE_BUILD_DIR"); dir != "" {
+		cc, err = relay.ContractConfigFromTruffleArtifacts(dir, chainID.String(), names)
+	} else if dir := getenv("TEST_BROADCAST_LOG_DIR"); dir != "" {
+		cc, err = relay.ContractConfigFromBroadcastLog(filepath.Join(dir, chainID.String(), "run-latest.json"), names)
+	}
+	if err != nil {
 		return nil, err
 	}
-	for _, tx := range log.Transactions {
-		if tx.TransactionType != "CREATE" {
-			continue
-		}
-		switch tx.ContractName {
-		case "OwnableIBCHandler":
-			cc.IBCHandlerAddress = tx.ContractAddress
-		case "ICS20TransferBank":
-			cc.ICS20TransferBankAddress = tx.ContractAddress
-		case "ICS20Bank":
-			cc.ICS20BankAddress = tx.ContractAddress
-		case "IBCCommitmentTestHelper":
-			cc.IBCCommitmentTestHelperAddress = tx.ContractAddress
-		case "ERC20Token":
-			cc.ERC20TokenAddress = tx.ContractAddress
-		}
+	override, err := relay.ContractConfigFromEnv(getenv, "TEST_")
+	if err != nil {
+		return nil, err
 	}
+	cc.Merge(*override)
 	if err := cc.Validate(); err != nil {
 		return nil, err
 	}
-	return &cc, nil
+	layout, err := relay.ResolveCommitmentLayout(ctx, cl, *cc)
+	if err != nil {
+		return nil, err
+	}
+	if err := relay.DiscoverContractConfigWithLayout(ctx, cl, layout, cc); err != nil {
+		return nil, err
+	}
+	return cc, nil
 }
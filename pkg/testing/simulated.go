@@ -0,0 +1,189 @@
+package testing
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"0fatih/yui-ibc-solidity/pkg/client"
+	"0fatih/yui-ibc-solidity/pkg/contract/ibchandler"
+	"0fatih/yui-ibc-solidity/pkg/contract/ics20bank"
+	ibcclient "0fatih/yui-ibc-solidity/pkg/ibc/core/client"
+	"0fatih/yui-ibc-solidity/pkg/ibc/relay"
+	"0fatih/yui-ibc-solidity/pkg/signer"
+	"0fatih/yui-ibc-solidity/pkg/wallet"
+)
+
+const (
+	// SimulatedMnemonic is the mnemonic used to derive the keys on simulated chains.
+	SimulatedMnemonic = "math razor capable expose worth grape metal sunset metal sudden usage scheme"
+	// SimulatedChainID is the chain ID of every simulated chain. It is fixed by the go-ethereum simulated backend.
+	SimulatedChainID int64 = 1337
+
+	// the number of key indexes funded in the genesis
+	simulatedFundedKeys uint32 = 10
+	simulatedGasLimit   uint64 = 30_000_000
+)
+
+var simulatedBalance = new(big.Int).Lsh(big.NewInt(1), 128)
+
+// NewSimulatedChain creates a go-ethereum simulated backend, deploys the IBC contracts from the embedded
+// artifacts with the same layout as Deploy.s.sol and returns a Chain connected to it through an in-process
+// JSON-RPC server. Every transaction is mined into its own block as soon as it is sent.
+//
+// The simulated backend does not produce headers that an IBFT2 client can verify, so the chain only supports
+// the mock client. The test fails if the artifacts are not embedded. (see `go generate ./pkg/testing`)
+func NewSimulatedChain(t *testing.T) *Chain {
+	ctx := context.Background()
+	keys := make([]*ecdsa.PrivateKey, simulatedFundedKeys)
+	alloc := make(core.GenesisAlloc)
+	for i := range keys {
+		key, err := wallet.GetPrvKeyFromMnemonicAndHDWPath(SimulatedMnemonic, fmt.Sprintf("m/44'/60'/0'/0/%v", i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[i] = key
+		alloc[gethcrypto.PubkeyToAddress(key.PublicKey)] = core.GenesisAccount{Balance: simulatedBalance}
+	}
+	backend := backends.NewSimulatedBackend(alloc, simulatedGasLimit)
+	t.Cleanup(func() { backend.Close() })
+
+	server := rpc.NewServer()
+	t.Cleanup(server.Stop)
+	if err := server.RegisterName("eth", newSimulatedAPI(backend)); err != nil {
+		t.Fatal(err)
+	}
+	ethClient := client.NewETHClientFromRPCClient(
+		rpc.DialInProc(server),
+		client.WithRetryOption(
+			retry.Delay(10*time.Millisecond),
+			retry.Attempts(10),
+		),
+	)
+
+	config, err := deploySimulatedContracts(ctx, ethClient, signer.TransactOpts(ctx, signer.NewPrivateKeySigner(keys[RelayerKeyIndex]), big.NewInt(SimulatedChainID)))
+	if errors.Is(err, ErrArtifactNotFound) {
+		// fail instead of skipping, so that the tests are never passed without running
+		t.Fatalf("%v: run `go generate ./pkg/testing` to embed the contract artifacts", err)
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	chain, err := relay.NewChain(ctx, ethClient, NewLightClient(ethClient, ibcclient.MockClient), signer.NewMnemonicKeyring(SimulatedMnemonic), *config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return chain
+}
+
+// NewSimulatedCoordinator creates n simulated chains and returns a Coordinator over them.
+func NewSimulatedCoordinator(t *testing.T, n int) Coordinator {
+	var chains []*Chain
+	for i := 0; i < n; i++ {
+		chains = append(chains, NewSimulatedChain(t))
+	}
+	return NewCoordinator(t, chains...)
+}
+
+func deploySimulatedContracts(ctx context.Context, cl *client.ETHClient, opts *bind.TransactOpts) (*ContractConfig, error) {
+	wait := func(tx *gethtypes.Transaction, err error) error {
+		if err != nil {
+			return err
+		}
+		rc, err := cl.WaitForReceiptAndGet(ctx, tx)
+		if err != nil {
+			return err
+		} else if rc.Status != gethtypes.ReceiptStatusSuccessful {
+			return fmt.Errorf("failed to execute a transaction: %v", tx.Hash())
+		}
+		return nil
+	}
+	deploy := func(name string, params ...interface{}) (common.Address, error) {
+		artifact, err := LoadContractArtifact(name)
+		if err != nil {
+			return common.Address{}, err
+		}
+		addr, tx, _, err := bind.DeployContract(opts, artifact.ABI, artifact.Bytecode, cl, params...)
+		if err := wait(tx, err); err != nil {
+			return common.Address{}, fmt.Errorf("failed to deploy %v: %w", name, err)
+		}
+		return addr, nil
+	}
+
+	var config ContractConfig
+	var err error
+
+	// deploy core contracts
+	var modules []interface{}
+	for _, name := range []string{"IBCClient", "IBCConnection", "IBCChannelHandshake", "IBCPacket"} {
+		addr, err := deploy(name)
+		if err != nil {
+			return nil, err
+		}
+		modules = append(modules, addr)
+	}
+	if config.IBCHandlerAddress, err = deploy("OwnableIBCHandler", modules...); err != nil {
+		return nil, err
+	}
+	handler, err := ibchandler.NewIbchandler(config.IBCHandlerAddress, cl)
+	if err != nil {
+		return nil, err
+	}
+
+	// deploy app contracts
+	if config.ICS20BankAddress, err = deploy("ICS20Bank"); err != nil {
+		return nil, err
+	}
+	if config.ICS20TransferBankAddress, err = deploy("ICS20TransferBank", config.IBCHandlerAddress, config.ICS20BankAddress); err != nil {
+		return nil, err
+	}
+	bank, err := ics20bank.NewIcs20bank(config.ICS20BankAddress, cl)
+	if err != nil {
+		return nil, err
+	}
+	if err := wait(bank.SetOperator(opts, config.ICS20TransferBankAddress)); err != nil {
+		return nil, err
+	}
+	if err := wait(handler.BindPort(opts, TransferPort, config.ICS20TransferBankAddress)); err != nil {
+		return nil, err
+	}
+
+	// deploy client contracts
+	for _, c := range []struct{ clientType, name string }{
+		{ibcclient.MockClient, "MockClient"},
+		{ibcclient.BesuIBFT2Client, "IBFT2Client"},
+	} {
+		addr, err := deploy(c.name, config.IBCHandlerAddress)
+		if err != nil {
+			return nil, err
+		}
+		if err := wait(handler.RegisterClient(opts, c.clientType, addr)); err != nil {
+			return nil, err
+		}
+	}
+
+	// deploy test helpers
+	if config.ERC20TokenAddress, err = deploy("ERC20Token", "test", "test", big.NewInt(1000000)); err != nil {
+		return nil, err
+	}
+	if config.IBCCommitmentTestHelperAddress, err = deploy("IBCCommitmentTestHelper"); err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
@@ -0,0 +1,363 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// simulatedAPI serves the subset of the `eth` JSON-RPC namespace that ETHClient and the contract bindings
+// use on top of a simulated backend. A sent transaction is committed into a new block immediately.
+type simulatedAPI struct {
+	backend *backends.SimulatedBackend
+}
+
+func newSimulatedAPI(backend *backends.SimulatedBackend) *simulatedAPI {
+	return &simulatedAPI{backend: backend}
+}
+
+type simulatedCallArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas"`
+	Value                *hexutil.Big    `json:"value"`
+	Data                 hexutil.Bytes   `json:"data"`
+	Input                hexutil.Bytes   `json:"input"`
+}
+
+func (args simulatedCallArgs) toCallMsg() ethereum.CallMsg {
+	msg := ethereum.CallMsg{
+		From:      args.From,
+		To:        args.To,
+		Gas:       uint64(args.Gas),
+		GasPrice:  (*big.Int)(args.GasPrice),
+		GasFeeCap: (*big.Int)(args.MaxFeePerGas),
+		GasTipCap: (*big.Int)(args.MaxPriorityFeePerGas),
+		Value:     (*big.Int)(args.Value),
+		Data:      args.Data,
+	}
+	if len(args.Input) > 0 {
+		msg.Data = args.Input
+	}
+	return msg
+}
+
+type simulatedFilterArgs struct {
+	BlockHash *common.Hash     `json:"blockHash"`
+	FromBlock *rpc.BlockNumber `json:"fromBlock"`
+	ToBlock   *rpc.BlockNumber `json:"toBlock"`
+	Addresses []common.Address `json:"address"`
+	Topics    [][]common.Hash  `json:"topics"`
+}
+
+type simulatedStorageResult struct {
+	Key   string       `json:"key"`
+	Value *hexutil.Big `json:"value"`
+	Proof []string     `json:"proof"`
+}
+
+type simulatedAccountResult struct {
+	Address      common.Address           `json:"address"`
+	AccountProof []string                 `json:"accountProof"`
+	Balance      *hexutil.Big             `json:"balance"`
+	CodeHash     common.Hash              `json:"codeHash"`
+	Nonce        hexutil.Uint64           `json:"nonce"`
+	StorageHash  common.Hash              `json:"storageHash"`
+	StorageProof []simulatedStorageResult `json:"storageProof"`
+}
+
+func (api *simulatedAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(SimulatedChainID))
+}
+
+func (api *simulatedAPI) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(api.backend.Blockchain().CurrentBlock().Number.Uint64())
+}
+
+func (api *simulatedAPI) GasPrice(ctx context.Context) (*hexutil.Big, error) {
+	price, err := api.backend.SuggestGasPrice(ctx)
+	return (*hexutil.Big)(price), err
+}
+
+func (api *simulatedAPI) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	tip, err := api.backend.SuggestGasTipCap(ctx)
+	return (*hexutil.Big)(tip), err
+}
+
+func (api *simulatedAPI) GetBalance(ctx context.Context, address common.Address, number rpc.BlockNumber) (*hexutil.Big, error) {
+	balance, err := api.backend.BalanceAt(ctx, address, toBlockNumber(number))
+	return (*hexutil.Big)(balance), err
+}
+
+func (api *simulatedAPI) GetCode(ctx context.Context, address common.Address, number rpc.BlockNumber) (hexutil.Bytes, error) {
+	if number == rpc.PendingBlockNumber {
+		return api.backend.PendingCodeAt(ctx, address)
+	}
+	return api.backend.CodeAt(ctx, address, toBlockNumber(number))
+}
+
+func (api *simulatedAPI) GetStorageAt(ctx context.Context, address common.Address, key common.Hash, number rpc.BlockNumber) (hexutil.Bytes, error) {
+	return api.backend.StorageAt(ctx, address, key, toBlockNumber(number))
+}
+
+func (api *simulatedAPI) GetTransactionCount(ctx context.Context, address common.Address, number rpc.BlockNumber) (hexutil.Uint64, error) {
+	var (
+		nonce uint64
+		err   error
+	)
+	if number == rpc.PendingBlockNumber {
+		nonce, err = api.backend.PendingNonceAt(ctx, address)
+	} else {
+		nonce, err = api.backend.NonceAt(ctx, address, toBlockNumber(number))
+	}
+	return hexutil.Uint64(nonce), err
+}
+
+func (api *simulatedAPI) Call(ctx context.Context, args simulatedCallArgs, number rpc.BlockNumber) (hexutil.Bytes, error) {
+	if number == rpc.PendingBlockNumber {
+		return api.backend.PendingCallContract(ctx, args.toCallMsg())
+	}
+	return api.backend.CallContract(ctx, args.toCallMsg(), toBlockNumber(number))
+}
+
+func (api *simulatedAPI) EstimateGas(ctx context.Context, args simulatedCallArgs) (hexutil.Uint64, error) {
+	gas, err := api.backend.EstimateGas(ctx, args.toCallMsg())
+	return hexutil.Uint64(gas), err
+}
+
+func (api *simulatedAPI) SendRawTransaction(ctx context.Context, input hexutil.Bytes) (common.Hash, error) {
+	tx := new(gethtypes.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+	if err := api.backend.SendTransaction(ctx, tx); err != nil {
+		return common.Hash{}, err
+	}
+	api.backend.Commit()
+	return tx.Hash(), nil
+}
+
+func (api *simulatedAPI) GetTransactionReceipt(ctx context.Context, hash common.Hash) (*gethtypes.Receipt, error) {
+	rc, err := api.backend.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	return rc, err
+}
+
+func (api *simulatedAPI) GetTransactionByHash(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	tx, _, err := api.backend.TransactionByHash(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	rc, err := api.backend.TransactionReceipt(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	return marshalSimulatedTransaction(tx, rc.BlockHash, rc.BlockNumber, rc.TransactionIndex)
+}
+
+// GetBlockByNumber returns null for a missing block like a node. The backend does not return ethereum.NotFound for
+// it, so the block is looked up in the blockchain when the backend fails, and the other errors are returned as is.
+func (api *simulatedAPI) GetBlockByNumber(ctx context.Context, number rpc.BlockNumber, fullTx bool) (map[string]interface{}, error) {
+	n := toBlockNumber(number)
+	block, err := api.backend.BlockByNumber(ctx, n)
+	if err != nil {
+		if n != nil && api.backend.Blockchain().GetHeaderByNumber(n.Uint64()) == nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return marshalSimulatedBlock(block, fullTx)
+}
+
+// GetBlockByHash returns null for a missing block in the same way as GetBlockByNumber.
+func (api *simulatedAPI) GetBlockByHash(ctx context.Context, hash common.Hash, fullTx bool) (map[string]interface{}, error) {
+	block, err := api.backend.BlockByHash(ctx, hash)
+	if err != nil {
+		if api.backend.Blockchain().GetHeaderByHash(hash) == nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return marshalSimulatedBlock(block, fullTx)
+}
+
+func (api *simulatedAPI) GetLogs(ctx context.Context, args simulatedFilterArgs) ([]gethtypes.Log, error) {
+	query := ethereum.FilterQuery{
+		BlockHash: args.BlockHash,
+		Addresses: args.Addresses,
+		Topics:    args.Topics,
+	}
+	if args.FromBlock != nil {
+		query.FromBlock = toBlockNumber(*args.FromBlock)
+	}
+	if args.ToBlock != nil {
+		query.ToBlock = toBlockNumber(*args.ToBlock)
+	}
+	logs, err := api.backend.FilterLogs(ctx, query)
+	if logs == nil {
+		logs = []gethtypes.Log{}
+	}
+	return logs, err
+}
+
+// Logs serves the "logs" subscription of eth_subscribe. The logs are notified when a block is committed.
+func (api *simulatedAPI) Logs(ctx context.Context, args simulatedFilterArgs) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	logs := make(chan gethtypes.Log)
+	sub, err := api.backend.SubscribeFilterLogs(context.Background(), ethereum.FilterQuery{
+		Addresses: args.Addresses,
+		Topics:    args.Topics,
+	}, logs)
+	if err != nil {
+		return nil, err
+	}
+	rpcSub := notifier.CreateSubscription()
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				if err := notifier.Notify(rpcSub.ID, &log); err != nil {
+					return
+				}
+			case <-sub.Err():
+				return
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+func (api *simulatedAPI) GetProof(ctx context.Context, address common.Address, storageKeys []common.Hash, number rpc.BlockNumber) (*simulatedAccountResult, error) {
+	header, err := api.backend.HeaderByNumber(ctx, toBlockNumber(number))
+	if err != nil {
+		return nil, err
+	}
+	state, err := api.backend.Blockchain().StateAt(header.Root)
+	if err != nil {
+		return nil, err
+	}
+	accountProof, err := state.GetProof(address)
+	if err != nil {
+		return nil, err
+	}
+	storageTrie, err := state.StorageTrie(address)
+	if err != nil {
+		return nil, err
+	}
+	storageHash := gethtypes.EmptyRootHash
+	if storageTrie != nil {
+		storageHash = storageTrie.Hash()
+	}
+	result := &simulatedAccountResult{
+		Address:      address,
+		AccountProof: toHexSlice(accountProof),
+		Balance:      (*hexutil.Big)(state.GetBalance(address)),
+		CodeHash:     state.GetCodeHash(address),
+		Nonce:        hexutil.Uint64(state.GetNonce(address)),
+		StorageHash:  storageHash,
+		StorageProof: []simulatedStorageResult{},
+	}
+	for _, key := range storageKeys {
+		proof, err := state.GetStorageProof(address, key)
+		if err != nil {
+			return nil, err
+		}
+		result.StorageProof = append(result.StorageProof, simulatedStorageResult{
+			Key:   key.Hex(),
+			Value: (*hexutil.Big)(state.GetState(address, key).Big()),
+			Proof: toHexSlice(proof),
+		})
+	}
+	return result, nil
+}
+
+// marshalSimulatedBlock encodes a block in the same way as go-ethereum's `eth_getBlockBy*` methods.
+func marshalSimulatedBlock(block *gethtypes.Block, fullTx bool) (map[string]interface{}, error) {
+	fields, err := toJSONMap(block.Header())
+	if err != nil {
+		return nil, err
+	}
+	fields["size"] = hexutil.Uint64(block.Size())
+	fields["uncles"] = []common.Hash{}
+	txs := []interface{}{}
+	for i, tx := range block.Transactions() {
+		if !fullTx {
+			txs = append(txs, tx.Hash())
+			continue
+		}
+		txFields, err := marshalSimulatedTransaction(tx, block.Hash(), block.Number(), uint(i))
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, txFields)
+	}
+	fields["transactions"] = txs
+	return fields, nil
+}
+
+// marshalSimulatedTransaction encodes a mined transaction in the same way as go-ethereum's `eth_getTransactionBy*` methods.
+func marshalSimulatedTransaction(tx *gethtypes.Transaction, blockHash common.Hash, blockNumber *big.Int, index uint) (map[string]interface{}, error) {
+	fields, err := toJSONMap(tx)
+	if err != nil {
+		return nil, err
+	}
+	from, err := gethtypes.Sender(gethtypes.LatestSignerForChainID(big.NewInt(SimulatedChainID)), tx)
+	if err != nil {
+		return nil, err
+	}
+	fields["from"] = from
+	fields["blockHash"] = blockHash
+	fields["blockNumber"] = (*hexutil.Big)(blockNumber)
+	fields["transactionIndex"] = hexutil.Uint64(index)
+	return fields, nil
+}
+
+func toJSONMap(v interface{}) (map[string]interface{}, error) {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(bz, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func toHexSlice(bzs [][]byte) []string {
+	ss := make([]string, len(bzs))
+	for i, bz := range bzs {
+		ss[i] = hexutil.Encode(bz)
+	}
+	return ss
+}
+
+// toBlockNumber converts a block number of the JSON-RPC into the one of the simulated backend,
+// which represents the latest block as nil.
+func toBlockNumber(number rpc.BlockNumber) *big.Int {
+	if number < 0 {
+		return nil
+	}
+	return big.NewInt(number.Int64())
+}
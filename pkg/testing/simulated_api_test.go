@@ -0,0 +1,37 @@
+package testing
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"0fatih/yui-ibc-solidity/pkg/client"
+)
+
+func TestSimulatedAPIMissingBlock(t *testing.T) {
+	ctx := context.Background()
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{}, simulatedGasLimit)
+	t.Cleanup(func() { backend.Close() })
+	server := rpc.NewServer()
+	t.Cleanup(server.Stop)
+	require.NoError(t, server.RegisterName("eth", newSimulatedAPI(backend)))
+	cl := client.NewETHClientFromRPCClient(rpc.DialInProc(server))
+
+	header, err := cl.HeaderByNumber(ctx, big.NewInt(0))
+	require.NoError(t, err)
+	_, err = cl.HeaderByHash(ctx, header.Hash())
+	require.NoError(t, err)
+
+	// a missing block is null, which the client reports as NotFound
+	_, err = cl.HeaderByNumber(ctx, big.NewInt(100))
+	require.ErrorIs(t, err, ethereum.NotFound)
+	_, err = cl.HeaderByHash(ctx, common.HexToHash("0x01"))
+	require.ErrorIs(t, err, ethereum.NotFound)
+}
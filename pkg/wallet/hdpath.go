@@ -0,0 +1,80 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcutil/hdkeychain"
+)
+
+// HDPath is a BIP-32 derivation path of any depth. The hardened levels have the bit hdkeychain.HardenedKeyStart.
+type HDPath []uint32
+
+// DefaultBasePath is the BIP-44 path of the first Ethereum account, to which the address index is appended.
+var DefaultBasePath = HDPath{
+	hdkeychain.HardenedKeyStart + 44,
+	hdkeychain.HardenedKeyStart + 60,
+	hdkeychain.HardenedKeyStart + 0,
+	0,
+}
+
+// ParseHDPath parses a derivation path such as "m/44'/60'/0'/0/1". A hardened level is marked with "'", "h" or "H".
+// The prefix "m/" is optional, and "m" is the path of the master key.
+func ParseHDPath(path string) (HDPath, error) {
+	path = strings.TrimSpace(path)
+	if path == "m" {
+		return HDPath{}, nil
+	}
+	path = strings.TrimPrefix(path, "m/")
+	if path == "" {
+		return nil, errors.New("empty path")
+	}
+	var hp HDPath
+	for _, level := range strings.Split(path, "/") {
+		var hardened bool
+		if n := len(level); n > 0 && (level[n-1] == '\'' || level[n-1] == 'h' || level[n-1] == 'H') {
+			level, hardened = level[:n-1], true
+		}
+		v, err := strconv.ParseUint(level, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path level '%v': %w", level, err)
+		} else if v >= hdkeychain.HardenedKeyStart {
+			return nil, fmt.Errorf("path level is out of range: %v", v)
+		}
+		if hardened {
+			v += hdkeychain.HardenedKeyStart
+		}
+		hp = append(hp, uint32(v))
+	}
+	return hp, nil
+}
+
+// Child returns the path extended with the levels.
+func (hp HDPath) Child(levels ...uint32) HDPath {
+	return append(append(HDPath{}, hp...), levels...)
+}
+
+// IsHardened returns true if any level is hardened, which cannot be derived from an extended public key.
+func (hp HDPath) IsHardened() bool {
+	for _, v := range hp {
+		if v >= hdkeychain.HardenedKeyStart {
+			return true
+		}
+	}
+	return false
+}
+
+func (hp HDPath) String() string {
+	var sb strings.Builder
+	sb.WriteString("m")
+	for _, v := range hp {
+		if v >= hdkeychain.HardenedKeyStart {
+			fmt.Fprintf(&sb, "/%v'", v-hdkeychain.HardenedKeyStart)
+		} else {
+			fmt.Fprintf(&sb, "/%v", v)
+		}
+	}
+	return sb.String()
+}
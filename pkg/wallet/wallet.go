@@ -9,6 +9,8 @@ import (
 
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/ethereum/go-ethereum/common"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
 	bip39 "github.com/tyler-smith/go-bip39"
 )
 
@@ -20,6 +22,7 @@ type HDPathLevel struct {
 	Index    uint32
 }
 
+// ParseHDPathLevel parses a BIP-44 path such as "m/44'/60'/0'/0/1". Use ParseHDPath for the other schemes.
 func ParseHDPathLevel(path string) (*HDPathLevel, error) {
 	parts := strings.Split(path, "/")
 	if len(parts) != 6 {
@@ -89,52 +92,122 @@ func (hp *HDPathLevel) String() string {
 	return fmt.Sprintf("m/%v'/%v'/%v'/%v/%v", hp.Purpose, hp.CoinType, hp.Account, hp.Change, hp.Index)
 }
 
+// Path returns the generalised path of the BIP-44 path.
+func (hp *HDPathLevel) Path() HDPath {
+	return HDPath{
+		hdkeychain.HardenedKeyStart + hp.Purpose,
+		hdkeychain.HardenedKeyStart + hp.CoinType,
+		hdkeychain.HardenedKeyStart + hp.Account,
+		hp.Change,
+		hp.Index,
+	}
+}
+
 func GetPrvKeyFromHDWallet(seed []byte, hp *HDPathLevel) (*ecdsa.PrivateKey, error) {
-	// Generate a new master node using the seed.
-	masterKey, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	return DerivePrvKey(seed, hp.Path())
+}
+
+// DeriveExtendedKey derives the extended private key at the path from the seed.
+func DeriveExtendedKey(seed []byte, path HDPath) (*hdkeychain.ExtendedKey, error) {
+	key, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
 	if err != nil {
 		return nil, err
 	}
-	// This gives the path: m/{purpose}'
-	acc, err := masterKey.Derive(hdkeychain.HardenedKeyStart + hp.Purpose)
+	return deriveChildren(key, path)
+}
+
+func deriveChildren(key *hdkeychain.ExtendedKey, path HDPath) (*hdkeychain.ExtendedKey, error) {
+	var err error
+	for _, v := range path {
+		if key, err = key.Derive(v); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// DerivePrvKey derives the private key at the path from the seed.
+func DerivePrvKey(seed []byte, path HDPath) (*ecdsa.PrivateKey, error) {
+	key, err := DeriveExtendedKey(seed, path)
 	if err != nil {
 		return nil, err
 	}
-	// This gives the path: m/{purpose}'/{coin_type}'
-	acc, err = acc.Derive(hdkeychain.HardenedKeyStart + hp.CoinType)
+	btcecPrivKey, err := key.ECPrivKey()
 	if err != nil {
 		return nil, err
 	}
-	// This gives the path: m/{purpose}'/{coin_type}'/{account}'
-	acc, err = acc.Derive(hdkeychain.HardenedKeyStart + hp.Account)
+	return btcecPrivKey.ToECDSA(), nil
+}
+
+// NewSeed returns the BIP-39 seed of the mnemonic and the passphrase, which is empty if the wallet does not use it.
+func NewSeed(mnemonic, passphrase string) ([]byte, error) {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, errors.New("invalid mnemonic")
+	}
+	return bip39.NewSeed(mnemonic, passphrase), nil
+}
+
+// GetPrvKeyFromMnemonicAndHDWPath derives the key at the path from the mnemonic without a passphrase.
+func GetPrvKeyFromMnemonicAndHDWPath(mnemonic, path string) (*ecdsa.PrivateKey, error) {
+	return GetPrvKeyFromMnemonicAndPassphrase(mnemonic, "", path)
+}
+
+// GetPrvKeyFromMnemonicAndPassphrase derives the key at the path from the mnemonic and the BIP-39 passphrase.
+func GetPrvKeyFromMnemonicAndPassphrase(mnemonic, passphrase, path string) (*ecdsa.PrivateKey, error) {
+	hp, err := ParseHDPath(path)
 	if err != nil {
 		return nil, err
 	}
-	// This gives the path: m/{purpose}'/{coin_type}'/{account}'/{change}
-	acc, err = acc.Derive(0 + hp.Change)
+	seed, err := NewSeed(mnemonic, passphrase)
 	if err != nil {
 		return nil, err
 	}
-	// This gives the path: m/{purpose}'/{coin_type}'/{account}'/{change}/{index}
-	acc, err = acc.Derive(hp.Index)
+	return DerivePrvKey(seed, hp)
+}
+
+// GetXPubFromMnemonic returns the extended public key (xpub) at the path, e.g. the account level "m/44'/60'/0'",
+// from which the addresses of the non-hardened descendants are derived without the private keys.
+func GetXPubFromMnemonic(mnemonic, passphrase, path string) (string, error) {
+	hp, err := ParseHDPath(path)
+	if err != nil {
+		return "", err
+	}
+	seed, err := NewSeed(mnemonic, passphrase)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	btcecPrivKey, err := acc.ECPrivKey()
+	key, err := DeriveExtendedKey(seed, hp)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	return btcecPrivKey.ToECDSA(), nil
+	pub, err := key.Neuter()
+	if err != nil {
+		return "", err
+	}
+	return pub.String(), nil
 }
 
-func GetPrvKeyFromMnemonicAndHDWPath(mnemonic, path string) (*ecdsa.PrivateKey, error) {
-	if !bip39.IsMnemonicValid(mnemonic) {
-		return nil, errors.New("invalid mnemonic")
+// GetAddressFromXPub derives the address at the path relative to the extended public key, e.g. "m/0/1" for the
+// second address of the external chain. The path must not have hardened levels.
+func GetAddressFromXPub(xpub string, path string) (common.Address, error) {
+	hp, err := ParseHDPath(path)
+	if err != nil {
+		return common.Address{}, err
+	} else if hp.IsHardened() {
+		return common.Address{}, fmt.Errorf("hardened path cannot be derived from an extended public key: %v", hp)
 	}
-	hp, err := ParseHDPathLevel(path)
+	key, err := hdkeychain.NewKeyFromString(xpub)
 	if err != nil {
-		return nil, err
+		return common.Address{}, err
+	} else if key.IsPrivate() {
+		return common.Address{}, errors.New("not an extended public key")
+	}
+	if key, err = deriveChildren(key, hp); err != nil {
+		return common.Address{}, err
+	}
+	pub, err := key.ECPubKey()
+	if err != nil {
+		return common.Address{}, err
 	}
-	seed := bip39.NewSeed(mnemonic, "")
-	return GetPrvKeyFromHDWallet(seed, hp)
+	return gethcrypto.PubkeyToAddress(*pub.ToECDSA()), nil
 }
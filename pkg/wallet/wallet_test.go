@@ -0,0 +1,80 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/ethereum/go-ethereum/common"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// the mnemonic of the development accounts of Hardhat and Anvil
+const testMnemonic = "test test test test test test test test test test test junk"
+
+func TestParseHDPath(t *testing.T) {
+	hp, err := ParseHDPath("m/44'/60'/0h/0/1")
+	require.NoError(t, err)
+	require.Equal(t, DefaultBasePath.Child(1), hp)
+	require.Equal(t, "m/44'/60'/0'/0/1", hp.String())
+	require.True(t, hp.IsHardened())
+
+	hp, err = ParseHDPath("0/1/2/3/4/5")
+	require.NoError(t, err)
+	require.Len(t, hp, 6)
+	require.False(t, hp.IsHardened())
+
+	hp, err = ParseHDPath("m")
+	require.NoError(t, err)
+	require.Empty(t, hp)
+
+	for _, path := range []string{"", "m/", "m/a", "m/1''", "m/2147483648", "m//1"} {
+		_, err := ParseHDPath(path)
+		require.Error(t, err, path)
+	}
+
+	// the strict BIP-44 parser is compatible
+	hpl, err := ParseHDPathLevel("m/44'/60'/0'/0/1")
+	require.NoError(t, err)
+	require.Equal(t, DefaultBasePath.Child(1), hpl.Path())
+}
+
+func TestDeriveFromMnemonic(t *testing.T) {
+	key, err := GetPrvKeyFromMnemonicAndHDWPath(testMnemonic, "m/44'/60'/0'/0/0")
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), gethcrypto.PubkeyToAddress(key.PublicKey))
+
+	// the passphrase changes the seed
+	key2, err := GetPrvKeyFromMnemonicAndPassphrase(testMnemonic, "passphrase", "m/44'/60'/0'/0/0")
+	require.NoError(t, err)
+	require.NotEqual(t, gethcrypto.PubkeyToAddress(key.PublicKey), gethcrypto.PubkeyToAddress(key2.PublicKey))
+
+	_, err = GetPrvKeyFromMnemonicAndHDWPath("invalid mnemonic", "m/44'/60'/0'/0/0")
+	require.Error(t, err)
+}
+
+func TestXPub(t *testing.T) {
+	// the test vector 1 of BIP-32
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+	key, err := DeriveExtendedKey(seed, HDPath{hdkeychain.HardenedKeyStart, 1})
+	require.NoError(t, err)
+	pub, err := key.Neuter()
+	require.NoError(t, err)
+	require.Equal(t, "xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ", pub.String())
+
+	// the addresses derived from the xpub of the account match the ones derived from the private keys
+	xpub, err := GetXPubFromMnemonic(testMnemonic, "", "m/44'/60'/0'")
+	require.NoError(t, err)
+	for _, index := range []string{"0", "1"} {
+		addr, err := GetAddressFromXPub(xpub, "m/0/"+index)
+		require.NoError(t, err)
+		key, err := GetPrvKeyFromMnemonicAndHDWPath(testMnemonic, "m/44'/60'/0'/0/"+index)
+		require.NoError(t, err)
+		require.Equal(t, gethcrypto.PubkeyToAddress(key.PublicKey), addr)
+	}
+
+	_, err = GetAddressFromXPub(xpub, "m/0'/0")
+	require.Error(t, err)
+}
@@ -0,0 +1,32 @@
+#!/usr/bin/env bash
+set -e
+
+ARTIFACTS_DIR=./pkg/testing/artifacts
+
+function copy_artifact() {
+  local source=$1;
+
+  jq '{abi: .abi, bytecode: {object: .bytecode.object}}' ./out/${source}.sol/${source}.json > ${ARTIFACTS_DIR}/${source}.json
+}
+
+function main() {
+  local srcs=(
+    "IBCClient"
+    "IBCConnection"
+    "IBCChannelHandshake"
+    "IBCPacket"
+    "OwnableIBCHandler"
+    "ICS20Bank"
+    "ICS20TransferBank"
+    "MockClient"
+    "IBFT2Client"
+    "ERC20Token"
+    "IBCCommitmentTestHelper"
+  )
+  mkdir -p ${ARTIFACTS_DIR}
+  for src in "${srcs[@]}" ; do
+    copy_artifact ${src}
+  done
+}
+
+main
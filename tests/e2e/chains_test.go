@@ -41,7 +41,7 @@ func (suite *ChainTestSuite) SetupTest() {
 	suite.coordinator = ibctesting.NewCoordinator(suite.T(), suite.chainA, suite.chainB)
 }
 
-func (suite ChainTestSuite) TestChannel() {
+func (suite *ChainTestSuite) TestChannel() {
 	ctx := context.Background()
 
 	const (
@@ -62,8 +62,11 @@ func (suite ChainTestSuite) TestChannel() {
 	var delayStartTimeForRecv time.Time
 	var delayStartTimeForAck time.Time
 
-	beforeLatestHeight := chainA.GetIBFT2ClientState(clientA).LatestHeight
-	beforeConsensusState := chainA.GetIBFT2ConsensusState(clientA, beforeLatestHeight)
+	beforeClientState, err := chainA.GetIBFT2ClientState(ctx, clientA)
+	suite.Require().NoError(err)
+	beforeLatestHeight := beforeClientState.LatestHeight
+	beforeConsensusState, err := chainA.GetIBFT2ConsensusState(ctx, clientA, beforeLatestHeight)
+	suite.Require().NoError(err)
 
 	/// Tests for Transfer module ///
 
@@ -123,7 +126,7 @@ func (suite ChainTestSuite) TestChannel() {
 			uint64(chainB.LastHeader().Number.Int64())+1000,
 		),
 	))
-	chainA.UpdateHeader()
+	suite.Require().NoError(chainA.UpdateHeader(ctx))
 	delayStartTimeForRecv = time.Now()
 	suite.Require().NoError(suite.coordinator.UpdateClient(ctx, chainB, chainA, clientB))
 
@@ -195,7 +198,7 @@ func (suite ChainTestSuite) TestChannel() {
 			uint64(chainA.LastHeader().Number.Int64())+1000,
 		),
 	))
-	chainB.UpdateHeader()
+	suite.Require().NoError(chainB.UpdateHeader(ctx))
 	delayStartTimeForRecv = time.Now()
 	suite.Require().NoError(suite.coordinator.UpdateClient(ctx, chainA, chainB, clientA))
 
@@ -251,11 +254,14 @@ func (suite ChainTestSuite) TestChannel() {
 	suite.Require().True(ok)
 	suite.Require().Equal(channeltypes.Channel_State(chanData.State), channeltypes.CLOSED)
 
-	afterLatestHeight := chainA.GetIBFT2ClientState(clientA).LatestHeight
+	afterClientState, err := chainA.GetIBFT2ClientState(ctx, clientA)
+	suite.Require().NoError(err)
+	afterLatestHeight := afterClientState.LatestHeight
 	suite.Require().Equal(afterLatestHeight.RevisionNumber, beforeLatestHeight.RevisionNumber)
 	suite.Require().True(afterLatestHeight.RevisionHeight > beforeLatestHeight.RevisionHeight)
 
-	beforeConsensusState2 := chainA.GetIBFT2ConsensusState(clientA, beforeLatestHeight)
+	beforeConsensusState2, err := chainA.GetIBFT2ConsensusState(ctx, clientA, beforeLatestHeight)
+	suite.Require().NoError(err)
 	suite.Require().Equal(beforeConsensusState, beforeConsensusState2)
 }
 
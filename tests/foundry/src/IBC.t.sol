@@ -111,6 +111,207 @@ contract IBCTest is Test {
         assertEq(connectionId, "connection-1");
     }
 
+    function testRecvPacketCommitsReceipt() public {
+        Packet.Data memory packet = getPacket();
+        IBCMsgs.MsgPacketRecv memory msg_ = IBCMsgs.MsgPacketRecv({
+            packet: packet,
+            proof: abi.encodePacked(sha256(abi.encodePacked(testPacketCommitment))),
+            proofHeight: Height.Data({revision_number: 0, revision_height: 1})
+        });
+        handler.recvPacket(msg_);
+        assertEq(
+            handler.getCommitment(
+                IBCCommitment.packetReceiptCommitmentKey(
+                    packet.destination_port, packet.destination_channel, packet.sequence
+                )
+            ),
+            keccak256(abi.encodePacked(uint8(1)))
+        );
+
+        // the packet cannot be received twice
+        vm.expectRevert();
+        handler.recvPacket(msg_);
+    }
+
+    function testTimeoutPacketUnordered() public {
+        Packet.Data memory packet = getPacket();
+        sendPacket(packet);
+        updateMockClient(packet.timeout_height.revision_height);
+        IBCMsgs.MsgTimeoutPacket memory msg_ = IBCMsgs.MsgTimeoutPacket({
+            packet: packet,
+            proof: bytes(""),
+            proofHeight: packet.timeout_height,
+            nextSequenceRecv: 0
+        });
+
+        // the packet receipt exists on the counterparty chain
+        msg_.proof = abi.encodePacked(sha256(abi.encodePacked(uint8(1))));
+        vm.expectRevert();
+        handler.timeoutPacket(msg_);
+
+        msg_.proof = bytes("");
+        handler.timeoutPacket(msg_);
+        (, bool found) = handler.getHashedPacketCommitment(packet.source_port, packet.source_channel, packet.sequence);
+        assertEq(found, false);
+        // an UNORDERED channel is not closed by the timeout
+        (Channel.Data memory channel,) = handler.getChannel(packet.source_port, packet.source_channel);
+        assertEq(uint8(channel.state), uint8(Channel.State.STATE_OPEN));
+
+        // the packet cannot be timed out twice
+        vm.expectRevert();
+        handler.timeoutPacket(msg_);
+    }
+
+    function testTimeoutOnClose() public {
+        Packet.Data memory packet = getPacket();
+        sendPacket(packet);
+        (Channel.Data memory counterpartyChannel,) = handler.getChannel(packet.source_port, packet.source_channel);
+        IBCMsgs.MsgTimeoutOnClose memory msg_ = IBCMsgs.MsgTimeoutOnClose({
+            packet: packet,
+            proof: bytes(""),
+            proofClose: abi.encodePacked(sha256(Channel.encode(counterpartyChannel))),
+            proofHeight: Height.Data({revision_number: 0, revision_height: 1}),
+            nextSequenceRecv: 0
+        });
+
+        // the counterparty channel is still OPEN
+        vm.expectRevert();
+        handler.timeoutOnClose(msg_);
+
+        // the packet is timed out before its timeout height once the counterparty channel is CLOSED
+        counterpartyChannel.state = Channel.State.STATE_CLOSED;
+        msg_.proofClose = abi.encodePacked(sha256(Channel.encode(counterpartyChannel)));
+        handler.timeoutOnClose(msg_);
+        (, bool found) = handler.getHashedPacketCommitment(packet.source_port, packet.source_channel, packet.sequence);
+        assertEq(found, false);
+    }
+
+    function testRecvPacketOrdered() public {
+        updateChannel(Channel.State.STATE_OPEN, Channel.Order.ORDER_ORDERED);
+        Packet.Data memory packet = getPacket();
+        IBCMsgs.MsgPacketRecv memory msg_ = IBCMsgs.MsgPacketRecv({
+            packet: packet,
+            proof: abi.encodePacked(sha256(abi.encodePacked(testPacketCommitment))),
+            proofHeight: Height.Data({revision_number: 0, revision_height: 1})
+        });
+        handler.recvPacket(msg_);
+        assertEq(handler.getNextSequenceRecv(packet.destination_port, packet.destination_channel), 2);
+        assertEq(
+            handler.getCommitment(
+                IBCCommitment.nextSequenceRecvCommitmentKey(packet.destination_port, packet.destination_channel)
+            ),
+            keccak256(abi.encodePacked(uint64(2)))
+        );
+
+        // the packet cannot be received twice
+        vm.expectRevert();
+        handler.recvPacket(msg_);
+        // the packets are received in order
+        msg_.packet.sequence = 3;
+        vm.expectRevert();
+        handler.recvPacket(msg_);
+    }
+
+    function testTimeoutPacketOrdered() public {
+        updateChannel(Channel.State.STATE_OPEN, Channel.Order.ORDER_ORDERED);
+        Packet.Data memory packet = getPacket();
+        sendPacket(packet);
+        IBCMsgs.MsgTimeoutPacket memory msg_ = IBCMsgs.MsgTimeoutPacket({
+            packet: packet,
+            proof: proveNextSequenceRecv(1),
+            proofHeight: Height.Data({revision_number: 0, revision_height: 1}),
+            nextSequenceRecv: 1
+        });
+
+        // the timeout has not been reached at the proof height
+        vm.expectRevert();
+        handler.timeoutPacket(msg_);
+
+        updateMockClient(packet.timeout_height.revision_height);
+        msg_.proofHeight = packet.timeout_height;
+
+        // the packet has been received if the next receive sequence is greater than its sequence
+        msg_.nextSequenceRecv = 2;
+        msg_.proof = proveNextSequenceRecv(2);
+        vm.expectRevert();
+        handler.timeoutPacket(msg_);
+        // the next receive sequence does not match the proof
+        msg_.nextSequenceRecv = 1;
+        vm.expectRevert();
+        handler.timeoutPacket(msg_);
+        msg_.proof = proveNextSequenceRecv(1);
+
+        // the packet does not match the commitment
+        bytes memory data = packet.data;
+        msg_.packet.data = bytes("{\"amount\": \"200\"}");
+        vm.expectRevert();
+        handler.timeoutPacket(msg_);
+        msg_.packet.data = data;
+
+        handler.timeoutPacket(msg_);
+        (, bool found) = handler.getHashedPacketCommitment(packet.source_port, packet.source_channel, packet.sequence);
+        assertEq(found, false);
+        // an ORDERED channel is closed by the timeout
+        (Channel.Data memory channel,) = handler.getChannel(packet.source_port, packet.source_channel);
+        assertEq(uint8(channel.state), uint8(Channel.State.STATE_CLOSED));
+        assertEq(
+            handler.getCommitment(IBCCommitment.channelCommitmentKey(packet.source_port, packet.source_channel)),
+            keccak256(Channel.encode(channel))
+        );
+
+        // the channel is CLOSED
+        vm.expectRevert();
+        handler.timeoutPacket(msg_);
+        // the commitment has been deleted
+        updateChannel(Channel.State.STATE_OPEN, Channel.Order.ORDER_ORDERED);
+        vm.expectRevert();
+        handler.timeoutPacket(msg_);
+    }
+
+    function testTimeoutPacketClosedChannel() public {
+        updateChannel(Channel.State.STATE_OPEN, Channel.Order.ORDER_ORDERED);
+        Packet.Data memory packet = getPacket();
+        sendPacket(packet);
+        updateMockClient(packet.timeout_height.revision_height);
+        updateChannel(Channel.State.STATE_CLOSED, Channel.Order.ORDER_ORDERED);
+
+        vm.expectRevert();
+        handler.timeoutPacket(
+            IBCMsgs.MsgTimeoutPacket({
+                packet: packet,
+                proof: proveNextSequenceRecv(1),
+                proofHeight: packet.timeout_height,
+                nextSequenceRecv: 1
+            })
+        );
+        (, bool found) = handler.getHashedPacketCommitment(packet.source_port, packet.source_channel, packet.sequence);
+        assertEq(found, true);
+    }
+
+    function testTimeoutPacketNotOrdered() public {
+        Packet.Data memory packet = getPacket();
+        sendPacket(packet);
+        updateMockClient(packet.timeout_height.revision_height);
+        IBCMsgs.MsgTimeoutPacket memory msg_ = IBCMsgs.MsgTimeoutPacket({
+            packet: packet,
+            proof: proveNextSequenceRecv(1),
+            proofHeight: packet.timeout_height,
+            nextSequenceRecv: 1
+        });
+
+        // the next receive sequence does not prove the timeout on an UNORDERED channel
+        vm.expectRevert();
+        handler.timeoutPacket(msg_);
+
+        // the channel of an unknown ordering
+        updateChannel(Channel.State.STATE_OPEN, Channel.Order.ORDER_NONE_UNSPECIFIED);
+        vm.expectRevert();
+        handler.timeoutPacket(msg_);
+        msg_.proof = bytes("");
+        vm.expectRevert();
+        handler.timeoutPacket(msg_);
+    }
+
     /* gas benchmarks */
 
     function testBenchmarkCreateMockClient() public {
@@ -175,6 +376,23 @@ contract IBCTest is Test {
         );
     }
 
+    function sendPacket(Packet.Data memory packet) internal {
+        handler.sendPacket(
+            packet.source_port, packet.source_channel, packet.timeout_height, packet.timeout_timestamp, packet.data
+        );
+    }
+
+    function updateChannel(Channel.State state, Channel.Order ordering) internal {
+        (Channel.Data memory channel,) = handler.getChannel(MOCK_PORT_ID, "channel-0");
+        channel.state = state;
+        channel.ordering = ordering;
+        handler.setChannel(MOCK_PORT_ID, "channel-0", channel);
+    }
+
+    function proveNextSequenceRecv(uint64 nextSequenceRecv) internal pure returns (bytes memory) {
+        return abi.encodePacked(sha256(abi.encodePacked(nextSequenceRecv)));
+    }
+
     function wrapAnyMockHeader(IbcLightclientsMockV1Header.Data memory header) internal pure returns (bytes memory) {
         Any.Data memory anyHeader;
         anyHeader.type_url = "/ibc.lightclients.mock.v1.Header";
@@ -23,6 +23,8 @@ contract MockApp is IIBCModule {
         override
     {}
 
+    function onTimeoutPacket(Packet.Data calldata packet, address relayer) external virtual override {}
+
     function onChanOpenInit(
         Channel.Order,
         string[] calldata,
@@ -51,6 +51,10 @@ contract TestableIBCHandler is OwnableIBCHandler {
         nextChannelSequence = sequence;
     }
 
+    function getCommitment(bytes32 key) external view returns (bytes32) {
+        return commitments[key];
+    }
+
     function claimCapabilityDirectly(bytes calldata name, address addr) external {
         for (uint32 i = 0; i < capabilities[name].length; i++) {
             require(capabilities[name][i] != addr);
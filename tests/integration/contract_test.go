@@ -6,20 +6,28 @@ import (
 	"math/big"
 	"strings"
 	"testing"
+	"time"
 
-	"0fatih/yui-ibc-solidity/pkg/client"
+	"0fatih/yui-ibc-solidity/pkg/contract/ibchandler"
 	channeltypes "0fatih/yui-ibc-solidity/pkg/ibc/core/channel"
 	clienttypes "0fatih/yui-ibc-solidity/pkg/ibc/core/client"
+	"0fatih/yui-ibc-solidity/pkg/ibc/core/commitment"
+	ethcommitment "0fatih/yui-ibc-solidity/pkg/ibc/core/commitment/ethereum"
+	"0fatih/yui-ibc-solidity/pkg/ibc/relay"
 	ibctesting "0fatih/yui-ibc-solidity/pkg/testing"
 
 	ibcclienttypes "github.com/cosmos/ibc-go/v4/modules/core/02-client/types"
 	host "github.com/cosmos/ibc-go/v4/modules/core/24-host"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
 /*
-NOTE: This test is intended to be run on ganache. Therefore, we are using MockClient instead of IBFT2Client.
+NOTE: This test is intended to be run on the simulated backend. Therefore, we are using MockClient instead of IBFT2Client.
 */
 type ContractTestSuite struct {
 	suite.Suite
@@ -30,12 +38,9 @@ type ContractTestSuite struct {
 }
 
 func (suite *ContractTestSuite) SetupTest() {
-	ethClient, err := client.NewETHClient("http://127.0.0.1:8545")
-	suite.Require().NoError(err)
-
-	suite.chainA = ibctesting.NewChain(suite.T(), ethClient, ibctesting.NewLightClient(ethClient, clienttypes.MockClient))
-	suite.chainB = ibctesting.NewChain(suite.T(), ethClient, ibctesting.NewLightClient(ethClient, clienttypes.MockClient))
-	suite.coordinator = ibctesting.NewCoordinator(suite.T(), suite.chainA, suite.chainB)
+	suite.coordinator = ibctesting.NewSimulatedCoordinator(suite.T(), 2)
+	suite.chainA = suite.coordinator.GetChain(0)
+	suite.chainB = suite.coordinator.GetChain(1)
 }
 
 func (suite *ContractTestSuite) TestIBCCompatibility() {
@@ -109,17 +114,18 @@ func (suite *ContractTestSuite) TestChannel() {
 
 	balance0, err := chainA.ERC20.BalanceOf(chainA.CallOpts(ctx, relayer), chainA.CallOpts(ctx, deployer).From)
 	suite.Require().NoError(err)
-	suite.Require().NoError(chainA.WaitIfNoError(ctx)(
-		chainA.ERC20.Approve(chainA.TxOpts(ctx, deployer), chainA.ContractConfig.ICS20BankAddress, big.NewInt(100)),
+	// approve and deposit a simple token to the bank without waiting for each receipt
+	suite.Require().NoError(chainA.SubmitBatch(ctx, deployer,
+		func(opts *bind.TransactOpts) (*gethtypes.Transaction, error) {
+			return chainA.ERC20.Approve(opts, chainA.ContractConfig.ICS20BankAddress, big.NewInt(100))
+		},
+		func(opts *bind.TransactOpts) (*gethtypes.Transaction, error) {
+			return chainA.ICS20Bank.Deposit(opts, chainA.ContractConfig.ERC20TokenAddress, big.NewInt(100), chainA.CallOpts(ctx, alice).From)
+		},
 	))
-
-	// deposit a simple token to the bank
-	suite.Require().NoError(chainA.WaitIfNoError(ctx)(chainA.ICS20Bank.Deposit(
-		chainA.TxOpts(ctx, deployer),
-		chainA.ContractConfig.ERC20TokenAddress,
-		big.NewInt(100),
-		chainA.CallOpts(ctx, alice).From,
-	)))
+	pending, err := chainA.PendingTxs(deployer)
+	suite.Require().NoError(err)
+	suite.Require().Empty(pending)
 
 	// ensure that the balance is reduced
 	balance1, err := chainA.ERC20.BalanceOf(chainA.CallOpts(ctx, relayer), chainA.CallOpts(ctx, deployer).From)
@@ -143,7 +149,7 @@ func (suite *ContractTestSuite) TestChannel() {
 			uint64(chainA.LastHeader().Number.Int64())+1000,
 		),
 	))
-	chainA.UpdateHeader()
+	suite.Require().NoError(chainA.UpdateHeader(ctx))
 	suite.Require().NoError(suite.coordinator.UpdateClient(ctx, chainB, chainA, clientB))
 
 	// ensure that escrow has correct balance
@@ -154,15 +160,33 @@ func (suite *ContractTestSuite) TestChannel() {
 	// relay the packet
 	transferPacket, err := chainA.GetLastSentPacket(ctx, chanA.PortID, chanA.ID)
 	suite.Require().NoError(err)
+	receiptPath := host.PacketReceiptKey(chanB.PortID, chanB.ID, transferPacket.Sequence)
+	_, err = chainB.QueryPathNonMembershipProof(ctx, chainA, clientA, receiptPath, nil)
+	suite.Require().NoError(err)
 	suite.Require().NoError(suite.coordinator.HandlePacketRecv(ctx, chainB, chainA, chanB, chanA, *transferPacket))
+	suite.Require().NoError(chainB.UpdateHeader(ctx))
+	_, err = chainB.QueryPathNonMembershipProof(ctx, chainA, clientA, receiptPath, chainB.LastHeader().Number)
+	suite.Require().Error(err)
 	suite.Require().NoError(suite.coordinator.HandlePacketAcknowledgement(ctx, chainA, chainB, chanA, chanB, *transferPacket, []byte{1}))
 
+	// ensure that the packet commitment is deleted
+	suite.Require().NoError(chainA.UpdateHeader(ctx))
+	packetPath := host.PacketCommitmentKey(chanA.PortID, chanA.ID, transferPacket.Sequence)
+	_, err = chainA.QueryPathNonMembershipProof(ctx, chainB, clientB, packetPath, chainA.LastHeader().Number)
+	suite.Require().NoError(err)
+
 	// ensure that chainB has correct balance
 	expectedDenom := fmt.Sprintf("%v/%v/%v", chanB.PortID, chanB.ID, baseDenom)
 	balance, err := chainB.ICS20Bank.BalanceOf(chainB.CallOpts(ctx, relayer), chainB.CallOpts(ctx, bob).From, expectedDenom)
 	suite.Require().NoError(err)
 	suite.Require().Equal(int64(100), balance.Int64())
 
+	// watch the packet sent by the next transfer
+	sendPackets := make(chan *ibchandler.IbchandlerSendPacket, 1)
+	sub, err := chainB.WatchSendPacket(ctx, nil, sendPackets)
+	suite.Require().NoError(err)
+	defer sub.Unsubscribe()
+
 	// try to transfer the token to chainA
 	suite.Require().NoError(chainB.WaitIfNoError(ctx)(
 		chainB.ICS20Transfer.SendTransfer(
@@ -175,12 +199,20 @@ func (suite *ContractTestSuite) TestChannel() {
 			uint64(chainB.LastHeader().Number.Int64())+1000,
 		),
 	))
-	chainB.UpdateHeader()
+	suite.Require().NoError(chainB.UpdateHeader(ctx))
 	suite.Require().NoError(suite.coordinator.UpdateClient(ctx, chainA, chainB, clientA))
 
 	// relay the packet
 	transferPacket, err = chainB.GetLastSentPacket(ctx, chanB.PortID, chanB.ID)
 	suite.Require().NoError(err)
+	select {
+	case ev := <-sendPackets:
+		suite.Require().Equal(transferPacket.Sequence, ev.Sequence)
+	case err := <-sub.Err():
+		suite.Require().NoError(err)
+	case <-time.After(10 * time.Second):
+		suite.FailNow("SendPacket event is not delivered")
+	}
 	suite.Require().NoError(suite.coordinator.HandlePacketRecv(ctx, chainA, chainB, chanA, chanB, *transferPacket))
 	suite.Require().NoError(suite.coordinator.HandlePacketAcknowledgement(ctx, chainB, chainA, chanB, chanA, *transferPacket, []byte{1}))
 
@@ -212,6 +244,292 @@ func (suite *ContractTestSuite) TestChannel() {
 	suite.Require().Equal(channeltypes.Channel_State(chanData.State), channeltypes.CLOSED)
 }
 
+func (suite *ContractTestSuite) TestPacketTimeout() {
+	ctx := context.Background()
+
+	const (
+		relayer         = ibctesting.RelayerKeyIndex // the key-index of relayer on chain
+		deployer        = ibctesting.RelayerKeyIndex // the key-index of contract deployer on chain
+		alice    uint32 = 1                          // the key-index of alice on chain
+		bob      uint32 = 2                          // the key-index of bob on chain
+	)
+
+	chainA := suite.chainA
+	chainB := suite.chainB
+
+	clientA, clientB := suite.coordinator.SetupClients(ctx, chainA, chainB, clienttypes.MockClient)
+	connA, connB := suite.coordinator.CreateConnection(ctx, chainA, chainB, clientA, clientB)
+	chanA, chanB := suite.coordinator.CreateChannel(ctx, chainA, chainB, connA, connB, ibctesting.TransferPort, ibctesting.TransferPort, channeltypes.UNORDERED)
+
+	baseDenom := strings.ToLower(chainA.ContractConfig.ERC20TokenAddress.String())
+	suite.Require().NoError(chainA.WaitIfNoError(ctx)(
+		chainA.ERC20.Approve(chainA.TxOpts(ctx, deployer), chainA.ContractConfig.ICS20BankAddress, big.NewInt(300)),
+	))
+	suite.Require().NoError(chainA.WaitIfNoError(ctx)(chainA.ICS20Bank.Deposit(
+		chainA.TxOpts(ctx, deployer),
+		chainA.ContractConfig.ERC20TokenAddress,
+		big.NewInt(300),
+		chainA.CallOpts(ctx, alice).From,
+	)))
+	bankA0, err := chainA.ICS20Bank.BalanceOf(chainA.CallOpts(ctx, relayer), chainA.CallOpts(ctx, alice).From, baseDenom)
+	suite.Require().NoError(err)
+
+	sendTransfer := func(timeoutHeight uint64) channeltypes.Packet {
+		suite.Require().NoError(chainA.WaitIfNoError(ctx)(
+			chainA.ICS20Transfer.SendTransfer(
+				chainA.TxOpts(ctx, alice),
+				baseDenom,
+				100,
+				chainB.CallOpts(ctx, bob).From,
+				chanA.PortID, chanA.ID,
+				timeoutHeight,
+			),
+		))
+		packet, err := chainA.GetLastSentPacket(ctx, chanA.PortID, chanA.ID)
+		suite.Require().NoError(err)
+		return *packet
+	}
+
+	// send a packet that times out on chainB and another one that chainB receives before the timeout
+	timeoutHeight := uint64(chainB.LastHeader().Number.Int64()) + 3
+	timeoutPacket := sendTransfer(timeoutHeight)
+	receivedPacket := sendTransfer(timeoutHeight)
+
+	// the packet cannot be timed out before the timeout height
+	suite.Require().Error(suite.coordinator.TimeoutPacket(ctx, chainA, chainB, chanA, chanB, timeoutPacket))
+	suite.Require().NoError(chainA.UpdateHeader(ctx))
+	suite.Require().NoError(suite.coordinator.UpdateClient(ctx, chainB, chainA, clientB))
+	suite.Require().NoError(suite.coordinator.HandlePacketRecv(ctx, chainB, chainA, chanB, chanA, receivedPacket))
+
+	// update the client of chainB on chainA to the timeout height
+	for chainB.LastHeader().Number.Uint64() < timeoutHeight {
+		suite.Require().NoError(suite.coordinator.UpdateClient(ctx, chainB, chainA, clientB))
+		suite.Require().NoError(chainB.UpdateHeader(ctx))
+		suite.Require().NoError(suite.coordinator.UpdateClient(ctx, chainA, chainB, clientA))
+	}
+
+	// the received packet cannot be timed out, since its receipt is committed on chainB
+	suite.Require().Error(suite.coordinator.TimeoutPacket(ctx, chainA, chainB, chanA, chanB, receivedPacket))
+	suite.Require().NoError(suite.coordinator.TimeoutPacket(ctx, chainA, chainB, chanA, chanB, timeoutPacket))
+	// the packet cannot be timed out twice
+	suite.Require().Error(suite.coordinator.TimeoutPacket(ctx, chainA, chainB, chanA, chanB, timeoutPacket))
+
+	// the tokens of the timed out packet are refunded and the UNORDERED channel is still open
+	bankA1, err := chainA.ICS20Bank.BalanceOf(chainA.CallOpts(ctx, relayer), chainA.CallOpts(ctx, alice).From, baseDenom)
+	suite.Require().NoError(err)
+	suite.Require().Equal(bankA0.Int64()-100, bankA1.Int64())
+	chanData, ok, err := chainA.IBCHandler.GetChannel(chainA.CallOpts(ctx, relayer), chanA.PortID, chanA.ID)
+	suite.Require().NoError(err)
+	suite.Require().True(ok)
+	suite.Require().Equal(channeltypes.OPEN, channeltypes.Channel_State(chanData.State))
+
+	// send a packet that does not time out, which cannot be timed out until chainB closes the channel
+	closedPacket := sendTransfer(uint64(chainB.LastHeader().Number.Int64()) + 1000)
+	suite.Require().NoError(chainA.UpdateHeader(ctx))
+	suite.Require().NoError(suite.coordinator.UpdateClient(ctx, chainB, chainA, clientB))
+	suite.Require().Error(suite.coordinator.TimeoutPacket(ctx, chainA, chainB, chanA, chanB, closedPacket))
+	suite.Require().Error(suite.coordinator.TimeoutOnClose(ctx, chainA, chainB, chanA, chanB, closedPacket))
+	suite.Require().NoError(suite.coordinator.ChanCloseInit(ctx, chainB, chainA, chanB))
+	suite.Require().NoError(suite.coordinator.TimeoutOnClose(ctx, chainA, chainB, chanA, chanB, closedPacket))
+
+	// the tokens of the packet are refunded
+	bankA2, err := chainA.ICS20Bank.BalanceOf(chainA.CallOpts(ctx, relayer), chainA.CallOpts(ctx, alice).From, baseDenom)
+	suite.Require().NoError(err)
+	suite.Require().Equal(bankA1.Int64(), bankA2.Int64())
+}
+
+func (suite *ContractTestSuite) TestOrderedChannel() {
+	ctx := context.Background()
+
+	const (
+		relayer         = ibctesting.RelayerKeyIndex // the key-index of relayer on chain
+		deployer        = ibctesting.RelayerKeyIndex // the key-index of contract deployer on chain
+		alice    uint32 = 1                          // the key-index of alice on chain
+		bob      uint32 = 2                          // the key-index of bob on chain
+	)
+
+	chainA := suite.chainA
+	chainB := suite.chainB
+
+	clientA, clientB := suite.coordinator.SetupClients(ctx, chainA, chainB, clienttypes.MockClient)
+	connA, connB := suite.coordinator.CreateConnection(ctx, chainA, chainB, clientA, clientB)
+	chanA, chanB := suite.coordinator.CreateChannel(ctx, chainA, chainB, connA, connB, ibctesting.TransferPort, ibctesting.TransferPort, channeltypes.ORDERED)
+
+	// the next receive sequence is committed when the channel is opened
+	suite.Require().NoError(chainB.UpdateHeader(ctx))
+	suite.Require().NoError(suite.coordinator.UpdateClient(ctx, chainA, chainB, clientA))
+	seq, _, err := chainB.QueryNextSequenceRecvProof(ctx, chainA, clientA, chanB, nil)
+	suite.Require().NoError(err)
+	suite.Require().Equal(uint64(1), seq)
+
+	baseDenom := strings.ToLower(chainA.ContractConfig.ERC20TokenAddress.String())
+	suite.Require().NoError(chainA.SubmitBatch(ctx, deployer,
+		func(opts *bind.TransactOpts) (*gethtypes.Transaction, error) {
+			return chainA.ERC20.Approve(opts, chainA.ContractConfig.ICS20BankAddress, big.NewInt(400))
+		},
+		func(opts *bind.TransactOpts) (*gethtypes.Transaction, error) {
+			return chainA.ICS20Bank.Deposit(opts, chainA.ContractConfig.ERC20TokenAddress, big.NewInt(400), chainA.CallOpts(ctx, alice).From)
+		},
+	))
+
+	// send three packets and relay them in reverse order
+	var packets []channeltypes.Packet
+	for i := 0; i < 3; i++ {
+		suite.Require().NoError(chainA.WaitIfNoError(ctx)(
+			chainA.ICS20Transfer.SendTransfer(
+				chainA.TxOpts(ctx, alice),
+				baseDenom,
+				100,
+				chainB.CallOpts(ctx, bob).From,
+				chanA.PortID, chanA.ID,
+				uint64(chainB.LastHeader().Number.Int64())+1000,
+			),
+		))
+		packet, err := chainA.GetLastSentPacket(ctx, chanA.PortID, chanA.ID)
+		suite.Require().NoError(err)
+		packets = append([]channeltypes.Packet{*packet}, packets...)
+	}
+	suite.Require().NoError(chainA.UpdateHeader(ctx))
+	suite.Require().NoError(suite.coordinator.UpdateClient(ctx, chainB, chainA, clientB))
+
+	// the packets cannot be relayed if the first one is missing
+	err = suite.coordinator.RelayOrderedPackets(ctx, chainB, chainA, chanB, chanA, packets[:2])
+	suite.Require().ErrorIs(err, relay.ErrSequenceGap)
+	suite.Require().NoError(suite.coordinator.RelayOrderedPackets(ctx, chainB, chainA, chanB, chanA, packets))
+	seq, err = chainB.QueryNextSequenceRecv(ctx, chanB.PortID, chanB.ID)
+	suite.Require().NoError(err)
+	suite.Require().Equal(uint64(4), seq)
+	// relaying the received packets again is a no-op
+	suite.Require().NoError(suite.coordinator.RelayOrderedPackets(ctx, chainB, chainA, chanB, chanA, packets))
+
+	for i := len(packets) - 1; i >= 0; i-- {
+		suite.Require().NoError(suite.coordinator.HandlePacketAcknowledgement(ctx, chainA, chainB, chanA, chanB, packets[i], []byte{1}))
+	}
+	seq, err = chainA.QueryNextSequenceAck(ctx, chanA.PortID, chanA.ID)
+	suite.Require().NoError(err)
+	suite.Require().Equal(uint64(4), seq)
+
+	// send a packet that times out two blocks later on chainB
+	bankA0, err := chainA.ICS20Bank.BalanceOf(chainA.CallOpts(ctx, relayer), chainA.CallOpts(ctx, alice).From, baseDenom)
+	suite.Require().NoError(err)
+	suite.Require().NoError(chainA.WaitIfNoError(ctx)(
+		chainA.ICS20Transfer.SendTransfer(
+			chainA.TxOpts(ctx, alice),
+			baseDenom,
+			100,
+			chainB.CallOpts(ctx, bob).From,
+			chanA.PortID, chanA.ID,
+			uint64(chainB.LastHeader().Number.Int64())+2,
+		),
+	))
+	timeoutPacket, err := chainA.GetLastSentPacket(ctx, chanA.PortID, chanA.ID)
+	suite.Require().NoError(err)
+
+	// the packet cannot be timed out before the timeout height
+	suite.Require().Error(suite.coordinator.TimeoutPacket(ctx, chainA, chainB, chanA, chanB, *timeoutPacket))
+	for i := 0; i < 2; i++ {
+		suite.Require().NoError(chainA.UpdateHeader(ctx))
+		suite.Require().NoError(suite.coordinator.UpdateClient(ctx, chainB, chainA, clientB))
+	}
+	suite.Require().NoError(chainB.UpdateHeader(ctx))
+	suite.Require().NoError(suite.coordinator.UpdateClient(ctx, chainA, chainB, clientA))
+
+	suite.Require().NoError(suite.coordinator.TimeoutPacket(ctx, chainA, chainB, chanA, chanB, *timeoutPacket))
+
+	// the tokens are refunded and the channel is closed
+	bankA1, err := chainA.ICS20Bank.BalanceOf(chainA.CallOpts(ctx, relayer), chainA.CallOpts(ctx, alice).From, baseDenom)
+	suite.Require().NoError(err)
+	suite.Require().Equal(bankA0.Int64(), bankA1.Int64())
+	channel, err := chainA.QueryChannel(ctx, chanA.PortID, chanA.ID)
+	suite.Require().NoError(err)
+	suite.Require().Equal(channeltypes.CLOSED, channel.State)
+	suite.Require().Equal(channeltypes.ORDERED, channel.Ordering)
+	suite.Require().NoError(chainA.UpdateHeader(ctx))
+	packetPath := host.PacketCommitmentKey(chanA.PortID, chanA.ID, timeoutPacket.Sequence)
+	_, err = chainA.QueryPathNonMembershipProof(ctx, chainB, clientB, packetPath, chainA.LastHeader().Number)
+	suite.Require().NoError(err)
+}
+
+func (suite *ContractTestSuite) TestStorageProof() {
+	ctx := context.Background()
+	chainA := suite.chainA
+	chainB := suite.chainB
+
+	clientA, clientB := suite.coordinator.SetupClients(ctx, chainA, chainB, clienttypes.MockClient)
+	connA, connB := suite.coordinator.CreateConnection(ctx, chainA, chainB, clientA, clientB)
+	chanA, _ := suite.coordinator.CreateChannel(ctx, chainA, chainB, connA, connB, ibctesting.TransferPort, ibctesting.TransferPort, channeltypes.UNORDERED)
+	suite.Require().NoError(chainA.UpdateHeader(ctx))
+
+	channel, err := chainA.QueryChannel(ctx, chanA.PortID, chanA.ID)
+	suite.Require().NoError(err)
+	value, err := proto.Marshal(channel)
+	suite.Require().NoError(err)
+	path := host.ChannelKey(chanA.PortID, chanA.ID)
+	proof, header, err := chainA.QueryStorageProof(ctx, path, chainA.LastHeader().Number)
+	suite.Require().NoError(err)
+	suite.Require().Equal(commitment.ChannelStateCommitmentSlot(chanA.PortID, chanA.ID), proof.Slot().Hex())
+
+	// a client of chainA verifies the proof with the state root only
+	bz, err := ethcommitment.MarshalAny(proof)
+	suite.Require().NoError(err)
+	decoded, err := ethcommitment.UnmarshalAny(bz)
+	suite.Require().NoError(err)
+	verifier := ethcommitment.NewVerifier(chainA.ContractConfig.IBCHandlerAddress, chainA.CommitmentLayout().CommitmentSlot)
+	suite.Require().NoError(verifier.VerifyMembership(header.Root, decoded, path, value))
+	suite.Require().Error(verifier.VerifyMembership(header.Root, decoded, path, []byte("other")))
+
+	// no packet has been sent
+	path = host.PacketCommitmentKey(chanA.PortID, chanA.ID, 1)
+	proof, header, err = chainA.QueryStorageProof(ctx, path, chainA.LastHeader().Number)
+	suite.Require().NoError(err)
+	suite.Require().NoError(verifier.VerifyNonMembership(header.Root, proof, path))
+}
+
+func (suite *ContractTestSuite) TestCommitmentLayout() {
+	ctx := context.Background()
+	chainA := suite.chainA
+	chainB := suite.chainB
+	suite.Require().Equal(commitment.DefaultLayout, chainA.CommitmentLayout())
+
+	// a handler without clients cannot be validated, so the configured slot is trusted
+	wrongSlot := common.BigToHash(big.NewInt(1))
+	config := chainA.ContractConfig
+	config.CommitmentSlot = &wrongSlot
+	layout, err := relay.ResolveCommitmentLayout(ctx, chainA.Client(), config)
+	suite.Require().NoError(err)
+	suite.Require().Equal(wrongSlot, layout.CommitmentSlot)
+
+	clientA, _ := suite.coordinator.SetupClients(ctx, chainA, chainB, clienttypes.MockClient)
+
+	// the configured slot is validated with the client state
+	_, err = relay.ResolveCommitmentLayout(ctx, chainA.Client(), config)
+	suite.Require().ErrorIs(err, commitment.ErrCommitmentMismatch)
+	config.CommitmentSlot = nil
+	layout, err = relay.ResolveCommitmentLayout(ctx, chainA.Client(), config)
+	suite.Require().NoError(err)
+	suite.Require().Equal(commitment.DefaultLayout, layout)
+
+	// the slot is found by probing the client state
+	cs, found, err := chainA.IBCHandler.GetClientState(chainA.CallOpts(ctx, ibctesting.RelayerKeyIndex), clientA)
+	suite.Require().NoError(err)
+	suite.Require().True(found)
+	slot, err := commitment.DetectCommitmentSlot(ctx, chainA.Client(), chainA.ContractConfig.IBCHandlerAddress, host.FullClientStateKey(clientA), cs, nil)
+	suite.Require().NoError(err)
+	suite.Require().Equal(commitment.DefaultCommitmentSlot, slot)
+}
+
+func (suite *ContractTestSuite) TestDiscoverContractConfig() {
+	ctx := context.Background()
+	chain := suite.chainA
+	config := ibctesting.ContractConfig{IBCHandlerAddress: chain.ContractConfig.IBCHandlerAddress}
+	suite.Require().NoError(relay.DiscoverContractConfigWithLayout(ctx, chain.Client(), chain.CommitmentLayout(), &config))
+	suite.Require().Equal(chain.ContractConfig.ICS20TransferBankAddress, config.ICS20TransferBankAddress)
+	suite.Require().Equal(chain.ContractConfig.ICS20BankAddress, config.ICS20BankAddress)
+	// the contracts that are not registered in the handler remain optional
+	suite.Require().Zero(config.ERC20TokenAddress)
+	suite.Require().NoError(config.Validate())
+}
+
 func TestContractTestSuite(t *testing.T) {
 	suite.Run(t, new(ContractTestSuite))
 }